@@ -19,6 +19,7 @@ type UserFeatures struct {
 	PostgresqlFlexibleServer PostgresqlFlexibleServerFeatures
 	MachineLearning          MachineLearningFeatures
 	RecoveryService          RecoveryServiceFeatures
+	VirtualNetworkPeering    VirtualNetworkPeeringFeatures
 }
 
 type CognitiveAccountFeatures struct {
@@ -96,3 +97,7 @@ type RecoveryServiceFeatures struct {
 	VMBackupStopProtectionAndRetainDataOnDestroy bool
 	PurgeProtectedItemsFromVaultOnDestroy        bool
 }
+
+type VirtualNetworkPeeringFeatures struct {
+	AllowVirtualNetworkAccessByDefault bool
+}
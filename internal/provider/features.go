@@ -338,6 +338,21 @@ func schemaFeatures(supportLegacyTestSuite bool) *pluginsdk.Schema {
 				},
 			},
 		},
+
+		"virtual_network_peering": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"allow_virtual_network_access_by_default": {
+						Type:     pluginsdk.TypeBool,
+						Optional: true,
+						Default:  true,
+					},
+				},
+			},
+		},
 	}
 
 	// this is a temporary hack to enable us to gradually add provider blocks to test configurations
@@ -571,5 +586,15 @@ func expandFeatures(input []interface{}) features.UserFeatures {
 		}
 	}
 
+	if raw, ok := val["virtual_network_peering"]; ok {
+		items := raw.([]interface{})
+		if len(items) > 0 {
+			virtualNetworkPeeringRaw := items[0].(map[string]interface{})
+			if v, ok := virtualNetworkPeeringRaw["allow_virtual_network_access_by_default"]; ok {
+				featuresMap.VirtualNetworkPeering.AllowVirtualNetworkAccessByDefault = v.(bool)
+			}
+		}
+	}
+
 	return featuresMap
 }
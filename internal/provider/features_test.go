@@ -82,6 +82,9 @@ func TestExpandFeatures(t *testing.T) {
 					VMBackupStopProtectionAndRetainDataOnDestroy: false,
 					PurgeProtectedItemsFromVaultOnDestroy:        false,
 				},
+				VirtualNetworkPeering: features.VirtualNetworkPeeringFeatures{
+					AllowVirtualNetworkAccessByDefault: true,
+				},
 			},
 		},
 		{
@@ -179,6 +182,11 @@ func TestExpandFeatures(t *testing.T) {
 							"purge_protected_items_from_vault_on_destroy":          true,
 						},
 					},
+					"virtual_network_peering": []interface{}{
+						map[string]interface{}{
+							"allow_virtual_network_access_by_default": true,
+						},
+					},
 				},
 			},
 			Expected: features.UserFeatures{
@@ -243,6 +251,9 @@ func TestExpandFeatures(t *testing.T) {
 					VMBackupStopProtectionAndRetainDataOnDestroy: true,
 					PurgeProtectedItemsFromVaultOnDestroy:        true,
 				},
+				VirtualNetworkPeering: features.VirtualNetworkPeeringFeatures{
+					AllowVirtualNetworkAccessByDefault: true,
+				},
 			},
 		},
 		{
@@ -340,6 +351,11 @@ func TestExpandFeatures(t *testing.T) {
 							"purge_protected_items_from_vault_on_destroy":          false,
 						},
 					},
+					"virtual_network_peering": []interface{}{
+						map[string]interface{}{
+							"allow_virtual_network_access_by_default": false,
+						},
+					},
 				},
 			},
 			Expected: features.UserFeatures{
@@ -404,6 +420,9 @@ func TestExpandFeatures(t *testing.T) {
 					VMBackupStopProtectionAndRetainDataOnDestroy: false,
 					PurgeProtectedItemsFromVaultOnDestroy:        false,
 				},
+				VirtualNetworkPeering: features.VirtualNetworkPeeringFeatures{
+					AllowVirtualNetworkAccessByDefault: false,
+				},
 			},
 		},
 	}
@@ -1536,3 +1555,68 @@ func TestExpandFeaturesRecoveryService(t *testing.T) {
 		}
 	}
 }
+
+func TestExpandFeaturesVirtualNetworkPeering(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    []interface{}
+		EnvVars  map[string]interface{}
+		Expected features.UserFeatures
+	}{
+		{
+			Name: "Empty Block",
+			Input: []interface{}{
+				map[string]interface{}{
+					"virtual_network_peering": []interface{}{},
+				},
+			},
+			Expected: features.UserFeatures{
+				VirtualNetworkPeering: features.VirtualNetworkPeeringFeatures{
+					AllowVirtualNetworkAccessByDefault: true,
+				},
+			},
+		},
+		{
+			Name: "Allow Virtual Network Access By Default Enabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"virtual_network_peering": []interface{}{
+						map[string]interface{}{
+							"allow_virtual_network_access_by_default": true,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				VirtualNetworkPeering: features.VirtualNetworkPeeringFeatures{
+					AllowVirtualNetworkAccessByDefault: true,
+				},
+			},
+		},
+		{
+			Name: "Allow Virtual Network Access By Default Disabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"virtual_network_peering": []interface{}{
+						map[string]interface{}{
+							"allow_virtual_network_access_by_default": false,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				VirtualNetworkPeering: features.VirtualNetworkPeeringFeatures{
+					AllowVirtualNetworkAccessByDefault: false,
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testData {
+		t.Logf("[DEBUG] Test Case: %q", testCase.Name)
+		result := expandFeatures(testCase.Input)
+		if !reflect.DeepEqual(result.VirtualNetworkPeering, testCase.Expected.VirtualNetworkPeering) {
+			t.Fatalf("Expected %+v but got %+v", result.VirtualNetworkPeering, testCase.Expected.VirtualNetworkPeering)
+		}
+	}
+}
@@ -5,11 +5,16 @@ package containers
 
 import (
 	"fmt"
+	"log"
+	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-06-02-preview/managedclusters"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/dns/2018-05-01/zones"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/operationalinsights/2020-08-01/workspaces"
 	"github.com/hashicorp/go-azure-sdk/sdk/environments"
 	commonValidate "github.com/hashicorp/terraform-provider-azurerm/helpers/validate"
@@ -31,6 +36,10 @@ const (
 	openServiceMeshKey              = "openServiceMesh"
 )
 
+// the versions of the Azure Policy addon which can be pinned - new versions are rolled out by the service, so rather than
+// maintaining a list any version in the form `v<N>` is accepted and validated by the API
+var kubernetesAzurePolicyVersionRegex = regexp.MustCompile(`^v[1-9][0-9]*$`)
+
 // The AKS API hard-codes which add-ons are supported in which environment
 // as such unfortunately we can't just send "disabled" - we need to strip
 // the unsupported addons from the HTTP response. As such this defines
@@ -59,6 +68,10 @@ func schemaKubernetesAddOns() map[string]*pluginsdk.Schema {
 						Required:     true,
 						ValidateFunc: validation.StringIsNotEmpty,
 					},
+					"identity_ready": {
+						Type:     pluginsdk.TypeBool,
+						Computed: true,
+					},
 					"connector_identity": {
 						Type:     pluginsdk.TypeList,
 						Computed: true,
@@ -86,6 +99,12 @@ func schemaKubernetesAddOns() map[string]*pluginsdk.Schema {
 			Type:     pluginsdk.TypeBool,
 			Optional: true,
 		},
+		"azure_policy_version": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			Default:      "v2",
+			ValidateFunc: validation.StringMatch(kubernetesAzurePolicyVersionRegex, "`azure_policy_version` must be in the format `v<N>`, for example `v2`"),
+		},
 		"confidential_computing": {
 			Type:     pluginsdk.TypeList,
 			MaxItems: 1,
@@ -99,6 +118,25 @@ func schemaKubernetesAddOns() map[string]*pluginsdk.Schema {
 				},
 			},
 		},
+		"dapr": {
+			Type:     pluginsdk.TypeList,
+			MaxItems: 1,
+			Optional: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"high_availability_enabled": {
+						Type:     pluginsdk.TypeBool,
+						Optional: true,
+						Default:  true,
+					},
+					"metrics_enabled": {
+						Type:     pluginsdk.TypeBool,
+						Optional: true,
+						Default:  true,
+					},
+				},
+			},
+		},
 		"http_application_routing_enabled": {
 			Type:     pluginsdk.TypeBool,
 			Optional: true,
@@ -107,6 +145,10 @@ func schemaKubernetesAddOns() map[string]*pluginsdk.Schema {
 			Type:     pluginsdk.TypeString,
 			Computed: true,
 		},
+		"http_application_routing_zone_id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
 		"oms_agent": {
 			Type:     pluginsdk.TypeList,
 			MaxItems: 1,
@@ -122,6 +164,10 @@ func schemaKubernetesAddOns() map[string]*pluginsdk.Schema {
 						Type:     pluginsdk.TypeBool,
 						Optional: true,
 					},
+					"identity_ready": {
+						Type:     pluginsdk.TypeBool,
+						Computed: true,
+					},
 					"oms_agent_identity": {
 						Type:     pluginsdk.TypeList,
 						Computed: true,
@@ -202,6 +248,10 @@ func schemaKubernetesAddOns() map[string]*pluginsdk.Schema {
 						Type:     pluginsdk.TypeString,
 						Computed: true,
 					},
+					"identity_ready": {
+						Type:     pluginsdk.TypeBool,
+						Computed: true,
+					},
 					"ingress_application_gateway_identity": {
 						Type:     pluginsdk.TypeList,
 						Computed: true,
@@ -252,7 +302,12 @@ func schemaKubernetesAddOns() map[string]*pluginsdk.Schema {
 							"key_vault_secrets_provider.0.secret_rotation_enabled",
 							"key_vault_secrets_provider.0.secret_rotation_interval",
 						},
-						ValidateFunc: containerValidate.Duration,
+						ValidateFunc:     containerValidate.Duration,
+						DiffSuppressFunc: suppressKubernetesDurationDiff,
+					},
+					"identity_ready": {
+						Type:     pluginsdk.TypeBool,
+						Computed: true,
 					},
 					"secret_identity": {
 						Type:     pluginsdk.TypeList,
@@ -287,10 +342,13 @@ func expandKubernetesAddOns(d *pluginsdk.ResourceData, input map[string]interfac
 		Enabled: false,
 	}
 
+	// only the addons which have changed are sent, so that when these are merged into the existing addon profiles the
+	// addons which are unchanged (or which the provider doesn't know about) are left as-is
+
 	addonProfiles := map[string]managedclusters.ManagedClusterAddonProfile{}
 
 	confidentialComputing := input["confidential_computing"].([]interface{})
-	if len(confidentialComputing) > 0 && confidentialComputing[0] != nil {
+	if len(confidentialComputing) > 0 && confidentialComputing[0] != nil && d.HasChange("confidential_computing") {
 		value := confidentialComputing[0].(map[string]interface{})
 		config := make(map[string]string)
 		quoteHelperEnabled := "false"
@@ -313,7 +371,7 @@ func expandKubernetesAddOns(d *pluginsdk.ResourceData, input map[string]interfac
 	}
 
 	omsAgent := input["oms_agent"].([]interface{})
-	if len(omsAgent) > 0 && omsAgent[0] != nil {
+	if len(omsAgent) > 0 && omsAgent[0] != nil && d.HasChange("oms_agent") {
 		value := omsAgent[0].(map[string]interface{})
 		config := make(map[string]string)
 
@@ -338,7 +396,7 @@ func expandKubernetesAddOns(d *pluginsdk.ResourceData, input map[string]interfac
 	}
 
 	aciConnector := input["aci_connector_linux"].([]interface{})
-	if len(aciConnector) > 0 && aciConnector[0] != nil {
+	if len(aciConnector) > 0 && aciConnector[0] != nil && d.HasChange("aci_connector_linux") {
 		value := aciConnector[0].(map[string]interface{})
 		config := make(map[string]string)
 
@@ -354,19 +412,19 @@ func expandKubernetesAddOns(d *pluginsdk.ResourceData, input map[string]interfac
 		addonProfiles[aciConnectorKey] = disabled
 	}
 
-	if ok := d.HasChange("azure_policy_enabled"); ok {
+	if ok := d.HasChange("azure_policy_enabled") || (d.HasChange("azure_policy_version") && input["azure_policy_enabled"].(bool)); ok {
 		v := input["azure_policy_enabled"].(bool)
 		props := managedclusters.ManagedClusterAddonProfile{
 			Enabled: v,
 			Config: pointer.To(map[string]string{
-				"version": "v2",
+				"version": input["azure_policy_version"].(string),
 			}),
 		}
 		addonProfiles[azurePolicyKey] = props
 	}
 
 	ingressApplicationGateway := input["ingress_application_gateway"].([]interface{})
-	if len(ingressApplicationGateway) > 0 && ingressApplicationGateway[0] != nil {
+	if len(ingressApplicationGateway) > 0 && ingressApplicationGateway[0] != nil && d.HasChange("ingress_application_gateway") {
 		value := ingressApplicationGateway[0].(map[string]interface{})
 		config := make(map[string]string)
 
@@ -402,13 +460,13 @@ func expandKubernetesAddOns(d *pluginsdk.ResourceData, input map[string]interfac
 	}
 
 	azureKeyVaultSecretsProvider := input["key_vault_secrets_provider"].([]interface{})
-	if len(azureKeyVaultSecretsProvider) > 0 && azureKeyVaultSecretsProvider[0] != nil {
+	if len(azureKeyVaultSecretsProvider) > 0 && azureKeyVaultSecretsProvider[0] != nil && d.HasChange("key_vault_secrets_provider") {
 		value := azureKeyVaultSecretsProvider[0].(map[string]interface{})
 		config := make(map[string]string)
 
 		enableSecretRotation := fmt.Sprintf("%t", value["secret_rotation_enabled"].(bool))
 		config["enableSecretRotation"] = enableSecretRotation
-		config["rotationPollInterval"] = value["secret_rotation_interval"].(string)
+		config["rotationPollInterval"] = normalizeKubernetesDuration(value["secret_rotation_interval"].(string))
 
 		addonProfiles[azureKeyvaultSecretsProviderKey] = managedclusters.ManagedClusterAddonProfile{
 			Enabled: true,
@@ -421,6 +479,64 @@ func expandKubernetesAddOns(d *pluginsdk.ResourceData, input map[string]interfac
 	return filterUnsupportedKubernetesAddOns(addonProfiles, env)
 }
 
+// mergeKubernetesAddOnProfiles merges the addon profiles which have changed into the existing addon profiles returned
+// by the API, so that addons which haven't changed - or which aren't supported by the provider - are sent back as-is.
+// Since the Portal may change the casing of the addon keys, these are matched case-insensitively.
+func mergeKubernetesAddOnProfiles(existing *map[string]managedclusters.ManagedClusterAddonProfile, changed *map[string]managedclusters.ManagedClusterAddonProfile) *map[string]managedclusters.ManagedClusterAddonProfile {
+	output := make(map[string]managedclusters.ManagedClusterAddonProfile)
+	if existing != nil {
+		for key, value := range *existing {
+			output[key] = value
+		}
+	}
+
+	if changed != nil {
+		for key, value := range *changed {
+			for existingKey := range output {
+				if strings.EqualFold(existingKey, key) {
+					delete(output, existingKey)
+				}
+			}
+			output[key] = value
+		}
+	}
+
+	return &output
+}
+
+// normalizeKubernetesDuration returns the duration in a canonical form, omitting any trailing zero units (e.g. `120s` and
+// `2m0s` both become `2m`) - values which can't be parsed as a duration are returned as-is
+func normalizeKubernetesDuration(input string) string {
+	duration, err := time.ParseDuration(input)
+	if err != nil {
+		return input
+	}
+
+	output := duration.String()
+	if strings.HasSuffix(output, "m0s") {
+		output = strings.TrimSuffix(output, "0s")
+	}
+	if strings.HasSuffix(output, "h0m") {
+		output = strings.TrimSuffix(output, "0m")
+	}
+
+	return output
+}
+
+// the API can return an equivalent duration in a different form (e.g. `120s` rather than `2m`), which isn't a diff
+func suppressKubernetesDurationDiff(_, old, new string, _ *pluginsdk.ResourceData) bool {
+	oldDuration, err := time.ParseDuration(old)
+	if err != nil {
+		return false
+	}
+	newDuration, err := time.ParseDuration(new)
+	if err != nil {
+		return false
+	}
+
+	return oldDuration == newDuration
+}
+
 func filterUnsupportedKubernetesAddOns(input map[string]managedclusters.ManagedClusterAddonProfile, env environments.Environment) (*map[string]managedclusters.ManagedClusterAddonProfile, error) {
 	filter := func(input map[string]managedclusters.ManagedClusterAddonProfile, key string) (map[string]managedclusters.ManagedClusterAddonProfile, error) {
 		output := input
@@ -455,8 +571,8 @@ func flattenKubernetesAddOns(profile map[string]managedclusters.ManagedClusterAd
 	aciConnector := kubernetesAddonProfileLocate(profile, aciConnectorKey)
 	if enabled := aciConnector.Enabled; enabled {
 		subnetName := ""
-		if v := aciConnector.Config; v != nil && (*v)["SubnetName"] != "" {
-			subnetName = (*v)["SubnetName"]
+		if v := kubernetesAddonProfilelocateInConfig(aciConnector.Config, "SubnetName"); v != "" {
+			subnetName = v
 		}
 
 		identity := flattenKubernetesClusterAddOnIdentityProfile(aciConnector.Identity)
@@ -464,14 +580,19 @@ func flattenKubernetesAddOns(profile map[string]managedclusters.ManagedClusterAd
 		aciConnectors = append(aciConnectors, map[string]interface{}{
 			"subnet_name":        subnetName,
 			"connector_identity": identity,
+			"identity_ready":     kubernetesClusterAddOnIdentityReady(aciConnectorKey, aciConnector.Identity),
 		})
 	}
 
 	azurePolicyEnabled := false
+	azurePolicyVersion := "v2"
 	azurePolicy := kubernetesAddonProfileLocate(profile, azurePolicyKey)
 	if enabledVal := azurePolicy.Enabled; enabledVal {
 		azurePolicyEnabled = enabledVal
 	}
+	if v := kubernetesAddonProfilelocateInConfig(azurePolicy.Config, "version"); v != "" {
+		azurePolicyVersion = v
+	}
 
 	confidentialComputings := make([]interface{}, 0)
 	confidentialComputing := kubernetesAddonProfileLocate(profile, confidentialComputingKey)
@@ -518,6 +639,7 @@ func flattenKubernetesAddOns(profile map[string]managedclusters.ManagedClusterAd
 			"log_analytics_workspace_id":      workspaceID,
 			"msi_auth_for_monitoring_enabled": useAADAuth,
 			"oms_agent_identity":              omsAgentIdentity,
+			"identity_ready":                  kubernetesClusterAddOnIdentityReady(omsAgentKey, omsAgent.Identity),
 		})
 	}
 
@@ -559,6 +681,7 @@ func flattenKubernetesAddOns(profile map[string]managedclusters.ManagedClusterAd
 			"subnet_cidr":                          subnetCIDR,
 			"subnet_id":                            subnetId,
 			"ingress_application_gateway_identity": ingressApplicationGatewayIdentity,
+			"identity_ready":                       kubernetesClusterAddOnIdentityReady(ingressApplicationGatewayKey, ingressApplicationGateway.Identity),
 		})
 	}
 
@@ -579,7 +702,7 @@ func flattenKubernetesAddOns(profile map[string]managedclusters.ManagedClusterAd
 		}
 
 		if v := kubernetesAddonProfilelocateInConfig(azureKeyVaultSecretsProvider.Config, "rotationPollInterval"); v != "" {
-			rotationPollInterval = v
+			rotationPollInterval = normalizeKubernetesDuration(v)
 		}
 
 		azureKeyvaultSecretsProviderIdentity := flattenKubernetesClusterAddOnIdentityProfile(azureKeyVaultSecretsProvider.Identity)
@@ -588,12 +711,14 @@ func flattenKubernetesAddOns(profile map[string]managedclusters.ManagedClusterAd
 			"secret_rotation_enabled":  enableSecretRotation,
 			"secret_rotation_interval": rotationPollInterval,
 			"secret_identity":          azureKeyvaultSecretsProviderIdentity,
+			"identity_ready":           kubernetesClusterAddOnIdentityReady(azureKeyvaultSecretsProviderKey, azureKeyVaultSecretsProvider.Identity),
 		})
 	}
 
 	return map[string]interface{}{
 		"aci_connector_linux":                aciConnectors,
 		"azure_policy_enabled":               azurePolicyEnabled,
+		"azure_policy_version":               azurePolicyVersion,
 		"confidential_computing":             confidentialComputings,
 		"http_application_routing_enabled":   httpApplicationRoutingEnabled,
 		"http_application_routing_zone_name": httpApplicationRoutingZone,
@@ -634,10 +759,106 @@ func flattenKubernetesClusterAddOnIdentityProfile(profile *managedclusters.UserA
 	return identity
 }
 
+// validateKubernetesClusterAddOnIdentityProfile returns an error when only some of the Client ID, Object ID and Resource
+// ID of the Managed Identity assigned to an addon are populated, which happens whilst AKS is (re-)provisioning the
+// identity - and which would otherwise be flattened into an identity block with empty values
+func validateKubernetesClusterAddOnIdentityProfile(profile *managedclusters.UserAssignedIdentity) error {
+	if profile == nil {
+		return nil
+	}
+
+	missing := make([]string, 0)
+	if pointer.From(profile.ClientId) == "" {
+		missing = append(missing, "Client ID")
+	}
+	if pointer.From(profile.ObjectId) == "" {
+		missing = append(missing, "Object ID")
+	}
+	if pointer.From(profile.ResourceId) == "" {
+		missing = append(missing, "Resource ID")
+	}
+
+	if len(missing) > 0 && len(missing) < 3 {
+		return fmt.Errorf("the Managed Identity is only partially assigned, missing the %s", strings.Join(missing, " and "))
+	}
+
+	return nil
+}
+
+// kubernetesClusterAddOnIdentityReady returns whether the Managed Identity assigned to an addon has been fully
+// provisioned - an identity which is only partially assigned (whilst AKS is (re-)provisioning it) is flattened as-is,
+// so that it isn't treated as a lost identity (see kubernetesAddOnsWithLostIdentity), but isn't reported as ready
+func kubernetesClusterAddOnIdentityReady(addOn string, profile *managedclusters.UserAssignedIdentity) bool {
+	if profile == nil {
+		return false
+	}
+
+	if err := validateKubernetesClusterAddOnIdentityProfile(profile); err != nil {
+		log.Printf("[DEBUG] the addon %q: %+v", addOn, err)
+		return false
+	}
+
+	return pointer.From(profile.ClientId) != ""
+}
+
+// the addons which are assigned a Managed Identity by AKS, and the computed block exposing that identity
+var kubernetesAddOnIdentityKeys = map[string]string{
+	"aci_connector_linux":         "connector_identity",
+	"ingress_application_gateway": "ingress_application_gateway_identity",
+	"key_vault_secrets_provider":  "secret_identity",
+	"oms_agent":                   "oms_agent_identity",
+}
+
+// kubernetesAddOnsWithLostIdentity returns the addons which are enabled but have lost the Managed Identity which was
+// previously assigned to them (and exposed in the prior state) server-side. Addons which have never been assigned an
+// identity (e.g. on a Kubernetes Cluster using a Service Principal) aren't included.
+func kubernetesAddOnsWithLostIdentity(prior, current map[string]interface{}) []string {
+	output := make([]string, 0)
+	for addOn, identityKey := range kubernetesAddOnIdentityKeys {
+		if !kubernetesAddOnHasIdentity(prior, addOn, identityKey) {
+			continue
+		}
+
+		raw, ok := current[addOn].([]interface{})
+		if !ok || len(raw) == 0 || raw[0] == nil {
+			continue
+		}
+
+		if !kubernetesAddOnHasIdentity(current, addOn, identityKey) {
+			output = append(output, addOn)
+		}
+	}
+	sort.Strings(output)
+
+	return output
+}
+
+func kubernetesAddOnHasIdentity(input map[string]interface{}, addOn, identityKey string) bool {
+	raw, ok := input[addOn].([]interface{})
+	if !ok || len(raw) == 0 || raw[0] == nil {
+		return false
+	}
+
+	identity, ok := raw[0].(map[string]interface{})[identityKey].([]interface{})
+	if !ok || len(identity) == 0 || identity[0] == nil {
+		return false
+	}
+
+	// an identity which is only partially assigned is still being provisioned, rather than lost
+	for _, key := range []string{"client_id", "object_id", "user_assigned_identity_id"} {
+		if v, ok := identity[0].(map[string]interface{})[key].(string); ok && v != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
 func collectKubernetesAddons(d *pluginsdk.ResourceData) map[string]interface{} {
 	return map[string]interface{}{
 		"aci_connector_linux":              d.Get("aci_connector_linux").([]interface{}),
 		"azure_policy_enabled":             d.Get("azure_policy_enabled").(bool),
+		"azure_policy_version":             d.Get("azure_policy_version").(string),
 		"confidential_computing":           d.Get("confidential_computing").([]interface{}),
 		"http_application_routing_enabled": d.Get("http_application_routing_enabled").(bool),
 		"oms_agent":                        d.Get("oms_agent").([]interface{}),
@@ -649,14 +870,38 @@ func collectKubernetesAddons(d *pluginsdk.ResourceData) map[string]interface{} {
 
 // when the Kubernetes Cluster is updated in the Portal - Azure updates the casing on the keys
 // meaning what's submitted could be different to what's returned..
+//
+// since the API can return the same addon under multiple casings (e.g. `omsagent` and `omsAgent`), the keys are
+// checked in a stable order - preferring an enabled addon - so that the addon flattened doesn't vary between reads
 func kubernetesAddonProfileLocate(profile map[string]managedclusters.ManagedClusterAddonProfile, key string) managedclusters.ManagedClusterAddonProfile {
-	for k, v := range profile {
+	keys := make([]string, 0)
+	for k := range profile {
 		if strings.EqualFold(k, key) {
-			return v
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
+		return managedclusters.ManagedClusterAddonProfile{}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if profile[k].Enabled {
+			return profile[k]
 		}
 	}
 
-	return managedclusters.ManagedClusterAddonProfile{}
+	return profile[keys[0]]
+}
+
+// kubernetesHttpApplicationRoutingZoneId returns the ID of the DNS Zone created by the HTTP Application Routing
+// add-on, which lives within the Node Resource Group of the Kubernetes Cluster
+func kubernetesHttpApplicationRoutingZoneId(subscriptionId, nodeResourceGroup, zoneName string) string {
+	if nodeResourceGroup == "" || zoneName == "" {
+		return ""
+	}
+
+	return zones.NewDnsZoneID(subscriptionId, nodeResourceGroup, zoneName).ID()
 }
 
 // when the Kubernetes Cluster is updated in the Portal - Azure updates the casing on the keys
@@ -0,0 +1,703 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package containers
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-06-02-preview/managedclusters"
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestFlattenKubernetesAddOnsAzurePolicyVersion(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    map[string]managedclusters.ManagedClusterAddonProfile
+		Expected string
+	}{
+		{
+			// existing clusters without the addon read back the default version
+			Name:     "addon not present",
+			Input:    map[string]managedclusters.ManagedClusterAddonProfile{},
+			Expected: "v2",
+		},
+		{
+			Name: "addon without a version",
+			Input: map[string]managedclusters.ManagedClusterAddonProfile{
+				azurePolicyKey: {
+					Enabled: true,
+				},
+			},
+			Expected: "v2",
+		},
+		{
+			// the casing of the keys returned from the API can differ
+			Name: "addon with a version",
+			Input: map[string]managedclusters.ManagedClusterAddonProfile{
+				"azurePolicy": {
+					Enabled: true,
+					Config: pointer.To(map[string]string{
+						"Version": "v3",
+					}),
+				},
+			},
+			Expected: "v3",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual := flattenKubernetesAddOns(v.Input)["azure_policy_version"]
+		if actual != v.Expected {
+			t.Fatalf("expected %q but got %q", v.Expected, actual)
+		}
+	}
+}
+
+func TestKubernetesAddOnsWithLostIdentity(t *testing.T) {
+	identity := []interface{}{
+		map[string]interface{}{
+			"client_id":                 "00000000-0000-0000-0000-000000000000",
+			"object_id":                 "00000000-0000-0000-0000-000000000000",
+			"user_assigned_identity_id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.ManagedIdentity/userAssignedIdentities/identity1",
+		},
+	}
+	withIdentity := map[string]interface{}{
+		"ingress_application_gateway": []interface{}{
+			map[string]interface{}{
+				"ingress_application_gateway_identity": identity,
+			},
+		},
+		"key_vault_secrets_provider": []interface{}{
+			map[string]interface{}{
+				"secret_identity": identity,
+			},
+		},
+		"oms_agent": []interface{}{
+			map[string]interface{}{
+				"oms_agent_identity": identity,
+			},
+		},
+	}
+	withoutIdentity := map[string]interface{}{
+		"ingress_application_gateway": []interface{}{
+			map[string]interface{}{
+				"ingress_application_gateway_identity": []interface{}{},
+			},
+		},
+		"key_vault_secrets_provider": []interface{}{
+			map[string]interface{}{
+				"secret_identity": []interface{}{},
+			},
+		},
+		"oms_agent": []interface{}{
+			map[string]interface{}{
+				"oms_agent_identity": []interface{}{},
+			},
+		},
+	}
+
+	testData := []struct {
+		Name     string
+		Prior    map[string]interface{}
+		Current  map[string]interface{}
+		Expected []string
+	}{
+		{
+			Name:     "No Addons",
+			Prior:    map[string]interface{}{},
+			Current:  map[string]interface{}{},
+			Expected: []string{},
+		},
+		{
+			Name:  "Disabled Addons",
+			Prior: withIdentity,
+			Current: map[string]interface{}{
+				"ingress_application_gateway": []interface{}{},
+				"key_vault_secrets_provider":  []interface{}{},
+				"oms_agent":                   []interface{}{},
+			},
+			Expected: []string{},
+		},
+		{
+			Name:     "Enabled Addons with Identities",
+			Prior:    withIdentity,
+			Current:  withIdentity,
+			Expected: []string{},
+		},
+		{
+			// e.g. a Kubernetes Cluster using a Service Principal, or an addon which doesn't report an identity
+			Name:     "Enabled Addons which have never had Identities",
+			Prior:    withoutIdentity,
+			Current:  withoutIdentity,
+			Expected: []string{},
+		},
+		{
+			Name:     "Enabled Addons not in the Prior State",
+			Prior:    map[string]interface{}{},
+			Current:  withoutIdentity,
+			Expected: []string{},
+		},
+		{
+			Name:  "Enabled Addons which have lost their Identities",
+			Prior: withIdentity,
+			Current: map[string]interface{}{
+				"ingress_application_gateway": withoutIdentity["ingress_application_gateway"],
+				"key_vault_secrets_provider":  withoutIdentity["key_vault_secrets_provider"],
+				"oms_agent":                   withIdentity["oms_agent"],
+			},
+			Expected: []string{"ingress_application_gateway", "key_vault_secrets_provider"},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual := kubernetesAddOnsWithLostIdentity(v.Prior, v.Current)
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
+
+func TestFlattenKubernetesClusterDataSourceAddOnsEnabled(t *testing.T) {
+	input := map[string]managedclusters.ManagedClusterAddonProfile{
+		// the casing of the keys can differ when the Kubernetes Cluster has been updated in the Portal
+		"azurePolicy": {
+			Enabled: true,
+		},
+		omsAgentKey: {
+			Enabled: true,
+		},
+		openServiceMeshKey: {
+			Enabled: false,
+		},
+	}
+	expected := map[string]interface{}{
+		"aci_connector_linux":         false,
+		"azure_policy":                true,
+		"confidential_computing":      false,
+		"http_application_routing":    false,
+		"ingress_application_gateway": false,
+		"key_vault_secrets_provider":  false,
+		"oms_agent":                   true,
+		"open_service_mesh":           false,
+	}
+
+	actual := flattenKubernetesClusterDataSourceAddOnsEnabled(input)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %+v but got %+v", expected, actual)
+	}
+}
+
+func TestKubernetesHttpApplicationRoutingZoneId(t *testing.T) {
+	testData := []struct {
+		Name              string
+		NodeResourceGroup string
+		ZoneName          string
+		Expected          string
+	}{
+		{
+			Name:              "no zone",
+			NodeResourceGroup: "MC_example-resources_example-aks_westeurope",
+			ZoneName:          "",
+			Expected:          "",
+		},
+		{
+			Name:              "no node resource group",
+			NodeResourceGroup: "",
+			ZoneName:          "0123456789abcdef0123.westeurope.aksapp.io",
+			Expected:          "",
+		},
+		{
+			Name:              "zone within the node resource group",
+			NodeResourceGroup: "MC_example-resources_example-aks_westeurope",
+			ZoneName:          "0123456789abcdef0123.westeurope.aksapp.io",
+			Expected:          "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/MC_example-resources_example-aks_westeurope/providers/Microsoft.Network/dnsZones/0123456789abcdef0123.westeurope.aksapp.io",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual := kubernetesHttpApplicationRoutingZoneId("12345678-1234-9876-4563-123456789012", v.NodeResourceGroup, v.ZoneName)
+		if actual != v.Expected {
+			t.Fatalf("expected %q but got %q", v.Expected, actual)
+		}
+	}
+}
+
+func TestKubernetesAddOnsRoundTrip(t *testing.T) {
+	workspaceId := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.OperationalInsights/workspaces/workspace1"
+	gatewayId := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Network/applicationGateways/gateway1"
+
+	config := map[string]interface{}{
+		"aci_connector_linux": []interface{}{
+			map[string]interface{}{
+				"subnet_name": "aci-subnet",
+			},
+		},
+		"azure_policy_enabled": true,
+		"azure_policy_version": "v3",
+		"confidential_computing": []interface{}{
+			map[string]interface{}{
+				"sgx_quote_helper_enabled": true,
+			},
+		},
+		"http_application_routing_enabled": true,
+		"ingress_application_gateway": []interface{}{
+			map[string]interface{}{
+				"gateway_id": gatewayId,
+			},
+		},
+		"key_vault_secrets_provider": []interface{}{
+			map[string]interface{}{
+				"secret_rotation_enabled":  true,
+				"secret_rotation_interval": "5m",
+			},
+		},
+		"oms_agent": []interface{}{
+			map[string]interface{}{
+				"log_analytics_workspace_id":      workspaceId,
+				"msi_auth_for_monitoring_enabled": true,
+			},
+		},
+		"open_service_mesh_enabled": true,
+	}
+
+	expected := map[string]interface{}{
+		"aci_connector_linux": []interface{}{
+			map[string]interface{}{
+				"subnet_name":        "aci-subnet",
+				"connector_identity": []interface{}{},
+				"identity_ready":     false,
+			},
+		},
+		"azure_policy_enabled": true,
+		"azure_policy_version": "v3",
+		"confidential_computing": []interface{}{
+			map[string]interface{}{
+				"sgx_quote_helper_enabled": true,
+			},
+		},
+		"http_application_routing_enabled":   true,
+		"http_application_routing_zone_name": "",
+		"ingress_application_gateway": []interface{}{
+			map[string]interface{}{
+				"gateway_id":                           gatewayId,
+				"gateway_name":                         "",
+				"effective_gateway_id":                 "",
+				"subnet_cidr":                          "",
+				"subnet_id":                            "",
+				"ingress_application_gateway_identity": []interface{}{},
+				"identity_ready":                       false,
+			},
+		},
+		"key_vault_secrets_provider": []interface{}{
+			map[string]interface{}{
+				"secret_rotation_enabled":  true,
+				"secret_rotation_interval": "5m",
+				"secret_identity":          []interface{}{},
+				"identity_ready":           false,
+			},
+		},
+		"oms_agent": []interface{}{
+			map[string]interface{}{
+				"log_analytics_workspace_id":      workspaceId,
+				"msi_auth_for_monitoring_enabled": true,
+				"oms_agent_identity":              []interface{}{},
+				"identity_ready":                  false,
+			},
+		},
+		"open_service_mesh_enabled": true,
+	}
+
+	// when the Kubernetes Cluster is updated in the Portal the casing of the addon and config keys can change, which
+	// flattening should be insensitive to
+	recase := func(input map[string]managedclusters.ManagedClusterAddonProfile) map[string]managedclusters.ManagedClusterAddonProfile {
+		output := make(map[string]managedclusters.ManagedClusterAddonProfile)
+		for key, profile := range input {
+			if profile.Config != nil {
+				addonConfig := make(map[string]string)
+				for k, v := range *profile.Config {
+					addonConfig[strings.ToLower(k)] = v
+				}
+				profile.Config = &addonConfig
+			}
+			output[strings.ToLower(key)] = profile
+		}
+		return output
+	}
+
+	testData := []struct {
+		Name      string
+		Transform func(map[string]managedclusters.ManagedClusterAddonProfile) map[string]managedclusters.ManagedClusterAddonProfile
+	}{
+		{
+			Name: "casing as submitted",
+			Transform: func(input map[string]managedclusters.ManagedClusterAddonProfile) map[string]managedclusters.ManagedClusterAddonProfile {
+				return input
+			},
+		},
+		{
+			Name:      "casing changed by the Portal",
+			Transform: recase,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		d := schema.TestResourceDataRaw(t, resourceKubernetesCluster().Schema, config)
+		profiles, err := expandKubernetesAddOns(d, collectKubernetesAddons(d), *environments.AzurePublic())
+		if err != nil {
+			t.Fatalf("expanding: %+v", err)
+		}
+
+		actual := flattenKubernetesAddOns(v.Transform(*profiles))
+		for key, value := range expected {
+			if !reflect.DeepEqual(actual[key], value) {
+				t.Fatalf("expected %q to be %+v but got %+v", key, value, actual[key])
+			}
+		}
+	}
+}
+
+func TestMergeKubernetesAddOnProfiles(t *testing.T) {
+	existing := map[string]managedclusters.ManagedClusterAddonProfile{
+		// an addon which isn't supported by the provider
+		"gitops": {
+			Enabled: true,
+		},
+		// casing changed by the Portal
+		"omsagent": {
+			Enabled: true,
+			Config: pointer.To(map[string]string{
+				"logAnalyticsWorkspaceResourceID": "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.OperationalInsights/workspaces/workspace1",
+			}),
+		},
+		azurePolicyKey: {
+			Enabled: true,
+			Config: pointer.To(map[string]string{
+				"version": "v2",
+			}),
+		},
+	}
+	changed := map[string]managedclusters.ManagedClusterAddonProfile{
+		omsAgentKey: {
+			Enabled: false,
+		},
+		openServiceMeshKey: {
+			Enabled: true,
+		},
+	}
+	expected := map[string]managedclusters.ManagedClusterAddonProfile{
+		"gitops": {
+			Enabled: true,
+		},
+		omsAgentKey: {
+			Enabled: false,
+		},
+		azurePolicyKey: {
+			Enabled: true,
+			Config: pointer.To(map[string]string{
+				"version": "v2",
+			}),
+		},
+		openServiceMeshKey: {
+			Enabled: true,
+		},
+	}
+
+	actual := mergeKubernetesAddOnProfiles(&existing, &changed)
+	if !reflect.DeepEqual(*actual, expected) {
+		t.Fatalf("expected %+v but got %+v", expected, *actual)
+	}
+
+	actual = mergeKubernetesAddOnProfiles(nil, &changed)
+	if !reflect.DeepEqual(*actual, changed) {
+		t.Fatalf("expected %+v but got %+v", changed, *actual)
+	}
+}
+
+func TestValidateKubernetesClusterAddOnIdentityProfile(t *testing.T) {
+	testData := []struct {
+		Name        string
+		Input       *managedclusters.UserAssignedIdentity
+		ShouldError bool
+		Ready       bool
+		Lost        bool
+	}{
+		{
+			Name:  "no identity",
+			Input: nil,
+			Lost:  true,
+		},
+		{
+			Name:  "empty identity",
+			Input: &managedclusters.UserAssignedIdentity{},
+			Lost:  true,
+		},
+		{
+			Name: "complete identity",
+			Input: &managedclusters.UserAssignedIdentity{
+				ClientId:   pointer.To("00000000-0000-0000-0000-000000000000"),
+				ObjectId:   pointer.To("11111111-1111-1111-1111-111111111111"),
+				ResourceId: pointer.To("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.ManagedIdentity/userAssignedIdentities/identity1"),
+			},
+			Ready: true,
+		},
+		{
+			Name: "missing object id",
+			Input: &managedclusters.UserAssignedIdentity{
+				ClientId:   pointer.To("00000000-0000-0000-0000-000000000000"),
+				ResourceId: pointer.To("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.ManagedIdentity/userAssignedIdentities/identity1"),
+			},
+			ShouldError: true,
+		},
+		{
+			Name: "only resource id",
+			Input: &managedclusters.UserAssignedIdentity{
+				ResourceId: pointer.To("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.ManagedIdentity/userAssignedIdentities/identity1"),
+			},
+			ShouldError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		err := validateKubernetesClusterAddOnIdentityProfile(v.Input)
+		if v.ShouldError && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !v.ShouldError && err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+
+		if actual := kubernetesClusterAddOnIdentityReady(omsAgentKey, v.Input); actual != v.Ready {
+			t.Fatalf("expected the identity to be ready %t but got %t", v.Ready, actual)
+		}
+
+		// a partially assigned identity is still being provisioned, so mustn't be treated as a lost identity
+		flattened := flattenKubernetesClusterAddOnIdentityProfile(v.Input)
+		prior := map[string]interface{}{
+			"oms_agent": []interface{}{
+				map[string]interface{}{
+					"oms_agent_identity": flattenKubernetesClusterAddOnIdentityProfile(&managedclusters.UserAssignedIdentity{
+						ClientId:   pointer.To("00000000-0000-0000-0000-000000000000"),
+						ObjectId:   pointer.To("11111111-1111-1111-1111-111111111111"),
+						ResourceId: pointer.To("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.ManagedIdentity/userAssignedIdentities/identity1"),
+					}),
+				},
+			},
+		}
+		lost := kubernetesAddOnsWithLostIdentity(prior, map[string]interface{}{
+			"oms_agent": []interface{}{
+				map[string]interface{}{
+					"oms_agent_identity": flattened,
+				},
+			},
+		})
+		if actual := len(lost) == 1; actual != v.Lost {
+			t.Fatalf("expected the identity to be lost %t but got %t (%+v)", v.Lost, actual, flattened)
+		}
+	}
+}
+
+func TestFlattenKubernetesAddOnsDuplicateCasing(t *testing.T) {
+	// the same addon can be returned under multiple casings, only one of which is enabled
+	profile := map[string]managedclusters.ManagedClusterAddonProfile{
+		"omsAgent": {
+			Enabled: false,
+		},
+		omsAgentKey: {
+			Enabled: true,
+			Config: pointer.To(map[string]string{
+				"logAnalyticsWorkspaceResourceID": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.OperationalInsights/workspaces/workspace1",
+			}),
+			Identity: &managedclusters.UserAssignedIdentity{
+				ClientId:   pointer.To("00000000-0000-0000-0000-000000000000"),
+				ObjectId:   pointer.To("11111111-1111-1111-1111-111111111111"),
+				ResourceId: pointer.To("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.ManagedIdentity/userAssignedIdentities/omsagent-cluster1"),
+			},
+		},
+	}
+
+	expected := flattenKubernetesAddOns(profile)
+	omsAgents := expected["oms_agent"].([]interface{})
+	if len(omsAgents) != 1 {
+		t.Fatalf("expected the enabled addon to be flattened but got %+v", omsAgents)
+	}
+	if identity := omsAgents[0].(map[string]interface{})["oms_agent_identity"].([]interface{}); len(identity) != 1 {
+		t.Fatalf("expected a single identity but got %+v", identity)
+	}
+
+	// map iteration order is randomised, so flatten repeatedly to check that the result doesn't vary between reads
+	for i := 0; i < 50; i++ {
+		if actual := flattenKubernetesAddOns(profile); !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("expected %+v but got %+v", expected, actual)
+		}
+	}
+}
+
+func TestFlattenKubernetesAddOnsNilConfig(t *testing.T) {
+	// Azure can return an enabled addon without any config, which shouldn't panic when flattened
+	profile := map[string]managedclusters.ManagedClusterAddonProfile{}
+	for _, key := range []string{
+		aciConnectorKey,
+		azureKeyvaultSecretsProviderKey,
+		azurePolicyKey,
+		confidentialComputingKey,
+		httpApplicationRoutingKey,
+		ingressApplicationGatewayKey,
+		omsAgentKey,
+		openServiceMeshKey,
+	} {
+		profile[key] = managedclusters.ManagedClusterAddonProfile{
+			Enabled: true,
+			Config:  nil,
+		}
+	}
+
+	resource := flattenKubernetesAddOns(profile)
+	if aciConnectors := resource["aci_connector_linux"].([]interface{}); len(aciConnectors) != 1 || aciConnectors[0].(map[string]interface{})["subnet_name"] != "" {
+		t.Fatalf("expected the aci connector to be flattened without a subnet name but got %+v", aciConnectors)
+	}
+
+	dataSource := flattenKubernetesClusterDataSourceAddOns(profile)
+	if aciConnectors := dataSource["aci_connector_linux"].([]interface{}); len(aciConnectors) != 1 || aciConnectors[0].(map[string]interface{})["subnet_name"] != "" {
+		t.Fatalf("expected the aci connector to be flattened without a subnet name but got %+v", aciConnectors)
+	}
+}
+
+func TestNormalizeKubernetesDuration(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Expected string
+	}{
+		{
+			Input:    "2m",
+			Expected: "2m",
+		},
+		{
+			Input:    "120s",
+			Expected: "2m",
+		},
+		{
+			Input:    "2m0s",
+			Expected: "2m",
+		},
+		{
+			Input:    "90s",
+			Expected: "1m30s",
+		},
+		{
+			Input:    "10s",
+			Expected: "10s",
+		},
+		{
+			Input:    "60m",
+			Expected: "1h",
+		},
+		{
+			Input:    "1h0m30s",
+			Expected: "1h0m30s",
+		},
+		{
+			Input:    "invalid",
+			Expected: "invalid",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		if actual := normalizeKubernetesDuration(v.Input); actual != v.Expected {
+			t.Fatalf("expected %q but got %q", v.Expected, actual)
+		}
+	}
+}
+
+func TestSuppressKubernetesDurationDiff(t *testing.T) {
+	testData := []struct {
+		Old      string
+		New      string
+		Suppress bool
+	}{
+		{
+			Old:      "2m",
+			New:      "120s",
+			Suppress: true,
+		},
+		{
+			Old:      "2m0s",
+			New:      "2m",
+			Suppress: true,
+		},
+		{
+			Old:      "120s",
+			New:      "2m0s",
+			Suppress: true,
+		},
+		{
+			Old:      "2m",
+			New:      "3m",
+			Suppress: false,
+		},
+		{
+			Old:      "",
+			New:      "2m",
+			Suppress: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q -> %q", v.Old, v.New)
+
+		if actual := suppressKubernetesDurationDiff("", v.Old, v.New, nil); actual != v.Suppress {
+			t.Fatalf("expected %t but got %t", v.Suppress, actual)
+		}
+	}
+}
+
+func TestExpandKubernetesAddOnsOpenServiceMeshDisabled(t *testing.T) {
+	// Open Service Mesh is enabled in the state (e.g. following an import) but is omitted from the config
+	state := &terraform.InstanceState{
+		ID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.ContainerService/managedClusters/cluster1",
+		Attributes: map[string]string{
+			"open_service_mesh_enabled": "true",
+		},
+	}
+
+	sm := schema.InternalMap(resourceKubernetesCluster().Schema)
+	diff, err := sm.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{}), nil, nil, true)
+	if err != nil {
+		t.Fatalf("diffing: %+v", err)
+	}
+	d, err := sm.Data(state, diff)
+	if err != nil {
+		t.Fatalf("building the resource data: %+v", err)
+	}
+
+	profiles, err := expandKubernetesAddOns(d, collectKubernetesAddons(d), *environments.AzurePublic())
+	if err != nil {
+		t.Fatalf("expanding: %+v", err)
+	}
+
+	openServiceMesh, ok := (*profiles)[openServiceMeshKey]
+	if !ok {
+		t.Fatalf("expected the %q addon to be sent", openServiceMeshKey)
+	}
+	if openServiceMesh.Enabled {
+		t.Fatalf("expected the %q addon to be disabled", openServiceMeshKey)
+	}
+}
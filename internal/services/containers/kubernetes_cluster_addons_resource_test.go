@@ -5,6 +5,7 @@ package containers_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
@@ -22,12 +23,27 @@ func TestAccKubernetesCluster_addonProfileAciConnectorLinux(t *testing.T) {
 			Config: r.addonProfileAciConnectorLinuxConfig(data),
 			Check: acceptance.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("aci_connector_linux.0.connector_identity.0.client_id").Exists(),
+				check.That(data.ResourceName).Key("aci_connector_linux.0.connector_identity.0.object_id").Exists(),
+				check.That(data.ResourceName).Key("aci_connector_linux.0.connector_identity.0.user_assigned_identity_id").Exists(),
 			),
 		},
 		data.ImportStep(),
 	})
 }
 
+func TestAccKubernetesCluster_addonProfileAciConnectorLinuxKubenet(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_kubernetes_cluster", "test")
+	r := KubernetesClusterResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.addonProfileAciConnectorLinuxKubenetConfig(data),
+			ExpectError: regexp.MustCompile("the `aci_connector_linux` addon requires Azure CNI"),
+		},
+	})
+}
+
 func TestAccKubernetesCluster_addonProfileAciConnectorLinuxDisabled(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_kubernetes_cluster", "test")
 	r := KubernetesClusterResource{}
@@ -53,6 +69,7 @@ func TestAccKubernetesCluster_addonProfileAzurePolicy(t *testing.T) {
 			Config: r.addonProfileAzurePolicyConfig(data, true),
 			Check: acceptance.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("azure_policy_version").HasValue("v2"),
 			),
 		},
 		data.ImportStep(),
@@ -69,6 +86,7 @@ func TestAccKubernetesCluster_addonProfileAzurePolicy(t *testing.T) {
 			Config: r.addonProfileAzurePolicyConfig(data, true),
 			Check: acceptance.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("azure_policy_version").HasValue("v2"),
 			),
 		},
 		data.ImportStep(),
@@ -143,6 +161,8 @@ func TestAccKubernetesCluster_addonProfileRoutingToggle(t *testing.T) {
 			Config: r.addonProfileRoutingConfig(data, true),
 			Check: acceptance.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("http_application_routing_zone_name").IsNotEmpty(),
+				check.That(data.ResourceName).Key("http_application_routing_zone_id").IsNotEmpty(),
 			),
 		},
 		data.ImportStep(),
@@ -232,6 +252,39 @@ func TestAccKubernetesCluster_addonProfileOpenServiceMesh(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesCluster_addonProfileDapr(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_kubernetes_cluster", "test")
+	r := KubernetesClusterResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.addonProfileDaprConfig(data, true, true),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("dapr.0.high_availability_enabled").HasValue("true"),
+				check.That(data.ResourceName).Key("dapr.0.metrics_enabled").HasValue("true"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.addonProfileDaprConfig(data, true, false),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("dapr.0.high_availability_enabled").HasValue("false"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.addonProfileDaprConfig(data, false, false),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("dapr.#").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccKubernetesCluster_addonProfileAzureKeyVaultSecretsProvider(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_kubernetes_cluster", "test")
 	r := KubernetesClusterResource{}
@@ -372,6 +425,44 @@ resource "azurerm_kubernetes_cluster" "test" {
 `, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger, data.RandomInteger, data.RandomInteger, data.RandomInteger, data.RandomInteger)
 }
 
+func (KubernetesClusterResource) addonProfileAciConnectorLinuxKubenetConfig(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-aks-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_kubernetes_cluster" "test" {
+  name                = "acctestaks%[1]d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+  dns_prefix          = "acctestaks%[1]d"
+
+  default_node_pool {
+    name       = "default"
+    node_count = 1
+    vm_size    = "Standard_DS2_v2"
+  }
+
+  aci_connector_linux {
+    subnet_name = "acctestsubnet-aci%[1]d"
+  }
+
+  identity {
+    type = "SystemAssigned"
+  }
+
+  network_profile {
+    network_plugin = "kubenet"
+  }
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
+
 func (KubernetesClusterResource) addonProfileAciConnectorLinuxDisabledConfig(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -1013,6 +1104,49 @@ resource "azurerm_kubernetes_cluster" "test" {
 `, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger, data.RandomInteger, enabled)
 }
 
+func (KubernetesClusterResource) addonProfileDaprConfig(data acceptance.TestData, enabled bool, highAvailability bool) string {
+	dapr := ""
+	if enabled {
+		dapr = fmt.Sprintf(`
+  dapr {
+    high_availability_enabled = %t
+    metrics_enabled           = true
+  }
+`, highAvailability)
+	}
+
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-aks-%d"
+  location = "%s"
+}
+
+resource "azurerm_kubernetes_cluster" "test" {
+  name                = "acctestaks%d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+  dns_prefix          = "acctestaks%d"
+
+  default_node_pool {
+    name       = "default"
+    node_count = 3
+    vm_size    = "Standard_DS2_v2"
+    upgrade_settings {
+      max_surge = "10%%"
+    }
+  }
+%s
+  identity {
+    type = "SystemAssigned"
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger, dapr)
+}
+
 func (KubernetesClusterResource) addonProfileAzureKeyVaultSecretsProviderConfig(data acceptance.TestData, secretRotation bool, rotationInterval string) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package containers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/kubernetesconfiguration/2022-11-01/extensions"
+)
+
+// Dapr is delivered as a Cluster Extension rather than as an addon within the addon profiles of the Kubernetes Cluster,
+// so the `dapr` block is managed using the Extensions API - with a fixed name so that it can be looked up again
+const (
+	kubernetesClusterDaprExtensionName             = "dapr"
+	kubernetesClusterDaprExtensionType             = "Microsoft.Dapr"
+	kubernetesClusterDaprExtensionReleaseNamespace = "dapr-system"
+
+	kubernetesClusterDaprHighAvailabilityKey = "global.ha.enabled"
+	kubernetesClusterDaprMetricsKey          = "global.prometheus.enabled"
+)
+
+func expandKubernetesClusterDaprExtension(input []interface{}) *extensions.Extension {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	raw := input[0].(map[string]interface{})
+	return &extensions.Extension{
+		Properties: &extensions.ExtensionProperties{
+			AutoUpgradeMinorVersion: pointer.To(true),
+			ExtensionType:           pointer.To(kubernetesClusterDaprExtensionType),
+			ConfigurationSettings: pointer.To(map[string]string{
+				kubernetesClusterDaprHighAvailabilityKey: strconv.FormatBool(raw["high_availability_enabled"].(bool)),
+				kubernetesClusterDaprMetricsKey:          strconv.FormatBool(raw["metrics_enabled"].(bool)),
+			}),
+			Scope: &extensions.Scope{
+				Cluster: &extensions.ScopeCluster{
+					ReleaseNamespace: pointer.To(kubernetesClusterDaprExtensionReleaseNamespace),
+				},
+			},
+		},
+	}
+}
+
+func flattenKubernetesClusterDaprExtension(input *extensions.Extension) []interface{} {
+	if input == nil || input.Properties == nil || !strings.EqualFold(pointer.From(input.Properties.ExtensionType), kubernetesClusterDaprExtensionType) {
+		return []interface{}{}
+	}
+
+	// both settings default to enabled when they're not specified
+	highAvailabilityEnabled := true
+	metricsEnabled := true
+	if settings := input.Properties.ConfigurationSettings; settings != nil {
+		if v, ok := (*settings)[kubernetesClusterDaprHighAvailabilityKey]; ok {
+			highAvailabilityEnabled = strings.EqualFold(v, "true")
+		}
+		if v, ok := (*settings)[kubernetesClusterDaprMetricsKey]; ok {
+			metricsEnabled = strings.EqualFold(v, "true")
+		}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"high_availability_enabled": highAvailabilityEnabled,
+			"metrics_enabled":           metricsEnabled,
+		},
+	}
+}
+
+// createOrUpdateKubernetesClusterDaprExtension installs, reconfigures or removes the Dapr Cluster Extension for the
+// Kubernetes Cluster to match the `dapr` block. `managed` is whether the Extension was previously managed through the
+// `dapr` block - an Extension which wasn't (e.g. one managed using the `azurerm_kubernetes_cluster_extension` resource)
+// is neither adopted nor deleted.
+func createOrUpdateKubernetesClusterDaprExtension(ctx context.Context, client *extensions.ExtensionsClient, clusterId commonids.KubernetesClusterId, input []interface{}, managed bool) error {
+	id := extensions.NewScopedExtensionID(clusterId.ID(), kubernetesClusterDaprExtensionName)
+
+	existing, err := client.Get(ctx, id)
+	if err != nil && !response.WasNotFound(existing.HttpResponse) {
+		return fmt.Errorf("retrieving Dapr Extension %s: %+v", id, err)
+	}
+
+	extension := expandKubernetesClusterDaprExtension(input)
+	if extension == nil {
+		if !managed || response.WasNotFound(existing.HttpResponse) {
+			return nil
+		}
+
+		if err := client.DeleteThenPoll(ctx, id, extensions.DefaultDeleteOperationOptions()); err != nil {
+			return fmt.Errorf("deleting Dapr Extension %s: %+v", id, err)
+		}
+		return nil
+	}
+
+	if response.WasNotFound(existing.HttpResponse) {
+		if err := client.CreateThenPoll(ctx, id, *extension); err != nil {
+			return fmt.Errorf("creating Dapr Extension %s: %+v", id, err)
+		}
+		return nil
+	}
+
+	if !managed {
+		return fmt.Errorf("the Dapr Extension %s already exists and isn't managed by the `dapr` block - either remove the `dapr` block and continue to manage the existing Extension, or remove the existing Extension", id)
+	}
+
+	patch := extensions.PatchExtension{
+		Properties: &extensions.PatchExtensionProperties{
+			AutoUpgradeMinorVersion: extension.Properties.AutoUpgradeMinorVersion,
+			ConfigurationSettings:   extension.Properties.ConfigurationSettings,
+		},
+	}
+	if err := client.UpdateThenPoll(ctx, id, patch); err != nil {
+		return fmt.Errorf("updating Dapr Extension %s: %+v", id, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package containers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKubernetesClusterDaprExtensionRoundTrip(t *testing.T) {
+	testData := []struct {
+		Name  string
+		Input []interface{}
+	}{
+		{
+			Name:  "disabled",
+			Input: []interface{}{},
+		},
+		{
+			Name: "defaults",
+			Input: []interface{}{
+				map[string]interface{}{
+					"high_availability_enabled": true,
+					"metrics_enabled":           true,
+				},
+			},
+		},
+		{
+			Name: "high availability and metrics disabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"high_availability_enabled": false,
+					"metrics_enabled":           false,
+				},
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual := flattenKubernetesClusterDaprExtension(expandKubernetesClusterDaprExtension(v.Input))
+		if !reflect.DeepEqual(actual, v.Input) {
+			t.Fatalf("expected %+v but got %+v", v.Input, actual)
+		}
+	}
+}
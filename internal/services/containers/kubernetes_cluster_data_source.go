@@ -53,6 +53,26 @@ func dataSourceKubernetesCluster() *pluginsdk.Resource {
 							Type:     pluginsdk.TypeString,
 							Computed: true,
 						},
+						"connector_identity": {
+							Type:     pluginsdk.TypeList,
+							Computed: true,
+							Elem: &pluginsdk.Resource{
+								Schema: map[string]*pluginsdk.Schema{
+									"client_id": {
+										Type:     pluginsdk.TypeString,
+										Computed: true,
+									},
+									"object_id": {
+										Type:     pluginsdk.TypeString,
+										Computed: true,
+									},
+									"user_assigned_identity_id": {
+										Type:     pluginsdk.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
 					},
 				},
 			},
@@ -223,6 +243,11 @@ func dataSourceKubernetesCluster() *pluginsdk.Resource {
 				Computed: true,
 			},
 
+			"http_application_routing_zone_id": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
 			"http_application_routing_zone_name": {
 				Type:     pluginsdk.TypeString,
 				Computed: true,
@@ -385,6 +410,14 @@ func dataSourceKubernetesCluster() *pluginsdk.Resource {
 				},
 			},
 
+			"addon_states": {
+				Type:     pluginsdk.TypeMap,
+				Computed: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeBool,
+				},
+			},
+
 			"open_service_mesh_enabled": {
 				Type:     pluginsdk.TypeBool,
 				Computed: true,
@@ -704,6 +737,13 @@ func dataSourceKubernetesCluster() *pluginsdk.Resource {
 							Type:     pluginsdk.TypeBool,
 							Computed: true,
 						},
+						"revisions": {
+							Type:     pluginsdk.TypeList,
+							Computed: true,
+							Elem: &pluginsdk.Schema{
+								Type: pluginsdk.TypeString,
+							},
+						},
 					},
 				},
 			},
@@ -775,10 +815,12 @@ func dataSourceKubernetesClusterRead(d *pluginsdk.ResourceData, meta interface{}
 				d.Set("azure_policy_enabled", addOns["azure_policy_enabled"].(bool))
 				d.Set("http_application_routing_enabled", addOns["http_application_routing_enabled"].(bool))
 				d.Set("http_application_routing_zone_name", addOns["http_application_routing_zone_name"])
+				d.Set("http_application_routing_zone_id", kubernetesHttpApplicationRoutingZoneId(id.SubscriptionId, nodeResourceGroup, addOns["http_application_routing_zone_name"].(string)))
 				d.Set("oms_agent", addOns["oms_agent"])
 				d.Set("ingress_application_gateway", addOns["ingress_application_gateway"])
 				d.Set("open_service_mesh_enabled", addOns["open_service_mesh_enabled"].(bool))
 				d.Set("key_vault_secrets_provider", addOns["key_vault_secrets_provider"])
+				d.Set("addon_states", addOns["addon_states"])
 			}
 
 			agentPoolProfiles := flattenKubernetesClusterDataSourceAgentPoolProfiles(props.AgentPoolProfiles)
@@ -1015,13 +1057,13 @@ func flattenKubernetesClusterDataSourceAddOns(profile map[string]managedclusters
 	aciConnectors := make([]interface{}, 0)
 	aciConnector := kubernetesAddonProfileLocate(profile, aciConnectorKey)
 	if enabled := aciConnector.Enabled; enabled {
-		subnetName := ""
-		if v := aciConnector.Config; v != nil && (*v)["SubnetName"] != "" {
-			subnetName = (*v)["SubnetName"]
-		}
+		subnetName := kubernetesAddonProfilelocateInConfig(aciConnector.Config, "SubnetName")
+
+		identity := flattenKubernetesClusterAddOnIdentityProfile(aciConnector.Identity)
 
 		aciConnectors = append(aciConnectors, map[string]interface{}{
-			"subnet_name": subnetName,
+			"subnet_name":        subnetName,
+			"connector_identity": identity,
 		})
 	}
 
@@ -1123,7 +1165,7 @@ func flattenKubernetesClusterDataSourceAddOns(profile map[string]managedclusters
 
 		rotationPollInterval := ""
 		if v := kubernetesAddonProfilelocateInConfig(azureKeyVaultSecretsProvider.Config, "rotationPollInterval"); v != "" {
-			rotationPollInterval = v
+			rotationPollInterval = normalizeKubernetesDuration(v)
 		}
 
 		azureKeyvaultSecretsProviderIdentity := flattenKubernetesClusterAddOnIdentityProfile(azureKeyVaultSecretsProvider.Identity)
@@ -1136,6 +1178,7 @@ func flattenKubernetesClusterDataSourceAddOns(profile map[string]managedclusters
 	}
 
 	return map[string]interface{}{
+		"addon_states":                       flattenKubernetesClusterDataSourceAddOnsEnabled(profile),
 		"aci_connector_linux":                aciConnectors,
 		"azure_policy_enabled":               azurePolicyEnabled,
 		"http_application_routing_enabled":   httpApplicationRoutingEnabled,
@@ -1147,6 +1190,27 @@ func flattenKubernetesClusterDataSourceAddOns(profile map[string]managedclusters
 	}
 }
 
+// flattenKubernetesClusterDataSourceAddOnsEnabled returns whether each addon is enabled, keyed by the name of the addon
+func flattenKubernetesClusterDataSourceAddOnsEnabled(profile map[string]managedclusters.ManagedClusterAddonProfile) map[string]interface{} {
+	addOnKeys := map[string]string{
+		"aci_connector_linux":         aciConnectorKey,
+		"azure_policy":                azurePolicyKey,
+		"confidential_computing":      confidentialComputingKey,
+		"http_application_routing":    httpApplicationRoutingKey,
+		"ingress_application_gateway": ingressApplicationGatewayKey,
+		"key_vault_secrets_provider":  azureKeyvaultSecretsProviderKey,
+		"oms_agent":                   omsAgentKey,
+		"open_service_mesh":           openServiceMeshKey,
+	}
+
+	output := make(map[string]interface{})
+	for name, key := range addOnKeys {
+		output[name] = kubernetesAddonProfileLocate(profile, key).Enabled
+	}
+
+	return output
+}
+
 func flattenKubernetesClusterDataSourceAgentPoolProfiles(input *[]managedclusters.ManagedClusterAgentPoolProfile) []interface{} {
 	agentPoolProfiles := make([]interface{}, 0)
 
@@ -1466,17 +1530,24 @@ func flattenKubernetesClusterDataSourceMicrosoftDefender(input *managedclusters.
 
 func flattenKubernetesClusterDataSourceUpgradeSettings(input *managedclusters.AgentPoolUpgradeSettings) []interface{} {
 	maxSurge := ""
-	if input != nil && input.MaxSurge != nil {
-		maxSurge = *input.MaxSurge
+	drainTimeoutInMinutes := 0
+	if input != nil {
+		if input.MaxSurge != nil {
+			maxSurge = *input.MaxSurge
+		}
+		if input.DrainTimeoutInMinutes != nil {
+			drainTimeoutInMinutes = int(*input.DrainTimeoutInMinutes)
+		}
 	}
 
-	if maxSurge == "" {
+	if maxSurge == "" && drainTimeoutInMinutes == 0 {
 		return []interface{}{}
 	}
 
 	return []interface{}{
 		map[string]interface{}{
-			"max_surge": maxSurge,
+			"max_surge":                maxSurge,
+			"drain_timeout_in_minutes": drainTimeoutInMinutes,
 		},
 	}
 }
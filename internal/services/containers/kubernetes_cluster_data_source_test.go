@@ -317,6 +317,26 @@ func TestAccDataSourceKubernetesCluster_advancedNetworkingKubenetComplete(t *tes
 	})
 }
 
+func TestAccDataSourceKubernetesCluster_addOnProfileAciConnectorLinux(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_kubernetes_cluster", "test")
+	r := KubernetesClusterDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.addOnProfileAciConnectorLinuxConfig(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("aci_connector_linux.#").HasValue("1"),
+				check.That(data.ResourceName).Key("aci_connector_linux.0.subnet_name").Exists(),
+				check.That(data.ResourceName).Key("aci_connector_linux.0.connector_identity.0.client_id").Exists(),
+				check.That(data.ResourceName).Key("aci_connector_linux.0.connector_identity.0.object_id").Exists(),
+				check.That(data.ResourceName).Key("aci_connector_linux.0.connector_identity.0.user_assigned_identity_id").Exists(),
+				check.That(data.ResourceName).Key("addon_states.aci_connector_linux").HasValue("true"),
+				check.That(data.ResourceName).Key("addon_states.oms_agent").HasValue("false"),
+			),
+		},
+	})
+}
+
 func TestAccDataSourceKubernetesCluster_addOnProfileOMS(t *testing.T) {
 	data := acceptance.BuildTestData(t, "data.azurerm_kubernetes_cluster", "test")
 	r := KubernetesClusterDataSource{}
@@ -330,6 +350,8 @@ func TestAccDataSourceKubernetesCluster_addOnProfileOMS(t *testing.T) {
 				check.That(data.ResourceName).Key("oms_agent.0.oms_agent_identity.0.client_id").Exists(),
 				check.That(data.ResourceName).Key("oms_agent.0.oms_agent_identity.0.object_id").Exists(),
 				check.That(data.ResourceName).Key("oms_agent.0.oms_agent_identity.0.user_assigned_identity_id").Exists(),
+				check.That(data.ResourceName).Key("addon_states.oms_agent").HasValue("true"),
+				check.That(data.ResourceName).Key("addon_states.aci_connector_linux").HasValue("false"),
 			),
 		},
 	})
@@ -359,6 +381,7 @@ func TestAccDataSourceKubernetesCluster_addOnProfileRouting(t *testing.T) {
 			Check: acceptance.ComposeTestCheckFunc(
 				check.That(data.ResourceName).Key("http_application_routing_enabled").HasValue("true"),
 				check.That(data.ResourceName).Key("http_application_routing_zone_name").Exists(),
+				check.That(data.ResourceName).Key("http_application_routing_zone_id").Exists(),
 			),
 		},
 	})
@@ -757,6 +780,17 @@ data "azurerm_kubernetes_cluster" "test" {
 `, KubernetesClusterResource{}.advancedNetworkingCompleteConfig(data, "kubenet", true))
 }
 
+func (KubernetesClusterDataSource) addOnProfileAciConnectorLinuxConfig(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_kubernetes_cluster" "test" {
+  name                = azurerm_kubernetes_cluster.test.name
+  resource_group_name = azurerm_kubernetes_cluster.test.resource_group_name
+}
+`, KubernetesClusterResource{}.addonProfileAciConnectorLinuxConfig(data))
+}
+
 func (KubernetesClusterDataSource) addOnProfileOMSConfig(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %s
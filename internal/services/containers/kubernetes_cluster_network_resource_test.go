@@ -95,6 +95,61 @@ func TestAccKubernetesCluster_serviceMeshProfileLifeCycle(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesCluster_serviceMeshProfileRevisionCanaryUpgrade(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_kubernetes_cluster", "test")
+	r := KubernetesClusterResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.serviceMeshProfileRevisions(data, `["asm-1-17"]`),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("service_mesh_profile.0.revisions.#").HasValue("1"),
+				check.That(data.ResourceName).Key("service_mesh_profile.0.revisions.0").HasValue("asm-1-17"),
+			),
+		},
+		data.ImportStep(),
+		{
+			// start the canary upgrade
+			Config: r.serviceMeshProfileRevisions(data, `["asm-1-17", "asm-1-18"]`),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("service_mesh_profile.0.revisions.#").HasValue("2"),
+			),
+		},
+		data.ImportStep(),
+		{
+			// complete the canary upgrade
+			Config: r.serviceMeshProfileRevisions(data, `["asm-1-18"]`),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("service_mesh_profile.0.revisions.#").HasValue("1"),
+				check.That(data.ResourceName).Key("service_mesh_profile.0.revisions.0").HasValue("asm-1-18"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccKubernetesCluster_serviceMeshProfileRevisionWithoutCanaryUpgrade(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_kubernetes_cluster", "test")
+	r := KubernetesClusterResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.serviceMeshProfileRevisions(data, `["asm-1-17"]`),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config:      r.serviceMeshProfileRevisions(data, `["asm-1-18"]`),
+			ExpectError: regexp.MustCompile("must be upgraded from \"asm-1-17\" to \"asm-1-18\" using a canary upgrade"),
+		},
+	})
+}
+
 func TestAccKubernetesCluster_advancedNetworkingIPVersionsIPv4(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_kubernetes_cluster", "test")
 	r := KubernetesClusterResource{}
@@ -1310,6 +1365,44 @@ resource "azurerm_kubernetes_cluster" "test" {
 `, data.RandomInteger, data.Locations.Primary, internalIngressEnabled, externalIngressEnabled)
 }
 
+func (KubernetesClusterResource) serviceMeshProfileRevisions(data acceptance.TestData, revisions string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-aks-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_kubernetes_cluster" "test" {
+  name                = "acctestaks%[1]d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+  dns_prefix          = "acctestaks%[1]d"
+
+  default_node_pool {
+    name       = "default"
+    node_count = 2
+    vm_size    = "Standard_DS2_v2"
+    upgrade_settings {
+      max_surge = "10%%"
+    }
+  }
+
+  identity {
+    type = "SystemAssigned"
+  }
+
+  service_mesh_profile {
+    mode      = "Istio"
+    revisions = %[3]s
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, revisions)
+}
+
 func (KubernetesClusterResource) serviceMeshProfileDisabled(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
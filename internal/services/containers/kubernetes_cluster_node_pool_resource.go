@@ -1084,6 +1084,11 @@ func upgradeSettingsSchema() *pluginsdk.Schema {
 						Type:     pluginsdk.TypeString,
 						Required: true,
 					},
+					"drain_timeout_in_minutes": {
+						Type:         pluginsdk.TypeInt,
+						Optional:     true,
+						ValidateFunc: validation.IntBetween(1, 1440),
+					},
 				},
 			},
 		}
@@ -1099,6 +1104,11 @@ func upgradeSettingsSchema() *pluginsdk.Schema {
 					Optional: true,
 					Default:  "10%",
 				},
+				"drain_timeout_in_minutes": {
+					Type:         pluginsdk.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntBetween(1, 1440),
+				},
 			},
 		},
 	}
@@ -1114,6 +1124,10 @@ func upgradeSettingsForDataSourceSchema() *pluginsdk.Schema {
 					Type:     pluginsdk.TypeString,
 					Computed: true,
 				},
+				"drain_timeout_in_minutes": {
+					Type:     pluginsdk.TypeInt,
+					Computed: true,
+				},
 			},
 		},
 	}
@@ -1170,22 +1184,32 @@ func expandAgentPoolUpgradeSettings(input []interface{}) *agentpools.AgentPoolUp
 	if maxSurgeRaw := v["max_surge"].(string); maxSurgeRaw != "" {
 		setting.MaxSurge = utils.String(maxSurgeRaw)
 	}
+	if drainTimeoutInMinutesRaw, ok := v["drain_timeout_in_minutes"].(int); ok && drainTimeoutInMinutesRaw != 0 {
+		setting.DrainTimeoutInMinutes = utils.Int64(int64(drainTimeoutInMinutesRaw))
+	}
 	return setting
 }
 
 func flattenAgentPoolUpgradeSettings(input *agentpools.AgentPoolUpgradeSettings) []interface{} {
 	maxSurge := ""
-	if input != nil && input.MaxSurge != nil {
-		maxSurge = *input.MaxSurge
+	drainTimeoutInMinutes := 0
+	if input != nil {
+		if input.MaxSurge != nil {
+			maxSurge = *input.MaxSurge
+		}
+		if input.DrainTimeoutInMinutes != nil {
+			drainTimeoutInMinutes = int(*input.DrainTimeoutInMinutes)
+		}
 	}
 
-	if maxSurge == "" {
+	if maxSurge == "" && drainTimeoutInMinutes == 0 {
 		return []interface{}{}
 	}
 
 	return []interface{}{
 		map[string]interface{}{
-			"max_surge": maxSurge,
+			"max_surge":                maxSurge,
+			"drain_timeout_in_minutes": drainTimeoutInMinutes,
 		},
 	}
 }
@@ -6,6 +6,7 @@ package containers_test
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -279,6 +280,7 @@ func TestAccKubernetesCluster_nodePoolKataMshvVmIsolation(t *testing.T) {
 			Config: r.nodePoolKataMshvVmIsolation(data),
 			Check: acceptance.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("default_node_pool.0.workload_runtime").HasValue("KataMshvVmIsolation"),
 			),
 		},
 		data.ImportStep(),
@@ -323,12 +325,25 @@ func TestAccKubernetesCluster_podSubnet(t *testing.T) {
 			Config: r.podSubnet(data),
 			Check: acceptance.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("default_node_pool.0.pod_subnet_id").MatchesOtherKey(check.That("azurerm_subnet.podsubnet").Key("id")),
 			),
 		},
 		data.ImportStep(),
 	})
 }
 
+func TestAccKubernetesCluster_podSubnetOverlay(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_kubernetes_cluster", "test")
+	r := KubernetesClusterResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.podSubnetOverlay(data),
+			ExpectError: regexp.MustCompile("`pod_subnet_id` can't be specified when `network_profile.0.network_plugin_mode` is set to `overlay`"),
+		},
+	})
+}
+
 func TestAccKubernetesCluster_upgrade(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_kubernetes_cluster", "test")
 	r := KubernetesClusterResource{}
@@ -602,12 +617,25 @@ func TestAccKubernetesCluster_capacityReservationGroup(t *testing.T) {
 			Config: r.capacityReservationGroup(data),
 			Check: acceptance.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("default_node_pool.0.capacity_reservation_group_id").MatchesOtherKey(check.That("azurerm_capacity_reservation_group.test").Key("id")),
 			),
 		},
 		data.ImportStep(),
 	})
 }
 
+func TestAccKubernetesCluster_capacityReservationGroupVMSizeMismatch(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_kubernetes_cluster", "test")
+	r := KubernetesClusterResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.capacityReservationGroupVMSize(data, "Standard_D4s_v3"),
+			ExpectError: regexp.MustCompile("no regional Capacity Reservation was found for the VM Size"),
+		},
+	})
+}
+
 func TestAccKubernetesCluster_completeMaintenanceConfigAutoUpgrade(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_kubernetes_cluster", "test")
 	r := KubernetesClusterResource{}
@@ -1907,6 +1935,66 @@ resource "azurerm_kubernetes_cluster" "test" {
 `, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger, data.RandomInteger)
 }
 
+func (KubernetesClusterResource) podSubnetOverlay(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-aks-%[1]d"
+  location = "%[2]s"
+}
+resource "azurerm_virtual_network" "test" {
+  name                = "acctestnw-%[1]d"
+  address_space       = ["10.0.0.0/8"]
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+}
+resource "azurerm_subnet" "nodesubnet" {
+  name                 = "nodesubnet"
+  resource_group_name  = azurerm_resource_group.test.name
+  virtual_network_name = azurerm_virtual_network.test.name
+  address_prefixes     = ["10.240.0.0/16"]
+}
+resource "azurerm_subnet" "podsubnet" {
+  name                 = "podsubnet"
+  resource_group_name  = azurerm_resource_group.test.name
+  virtual_network_name = azurerm_virtual_network.test.name
+  address_prefixes     = ["10.241.0.0/16"]
+  delegation {
+    name = "aks-delegation"
+    service_delegation {
+      actions = [
+        "Microsoft.Network/virtualNetworks/subnets/join/action",
+      ]
+      name = "Microsoft.ContainerService/managedClusters"
+    }
+  }
+}
+resource "azurerm_kubernetes_cluster" "test" {
+  name                = "acctestaks%[1]d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+  dns_prefix          = "acctestaks%[1]d"
+  default_node_pool {
+    name           = "default"
+    node_count     = 1
+    vm_size        = "Standard_DS2_v2"
+    pod_subnet_id  = azurerm_subnet.podsubnet.id
+    vnet_subnet_id = azurerm_subnet.nodesubnet.id
+  }
+  network_profile {
+    network_plugin      = "azure"
+    network_plugin_mode = "overlay"
+    pod_cidr            = "192.168.0.0/16"
+  }
+  identity {
+    type = "SystemAssigned"
+  }
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
+
 func (KubernetesClusterResource) skuConfigFree(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -2494,7 +2582,11 @@ resource "azurerm_kubernetes_cluster" "test" {
 `, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger)
 }
 
-func (KubernetesClusterResource) capacityReservationGroup(data acceptance.TestData) string {
+func (r KubernetesClusterResource) capacityReservationGroup(data acceptance.TestData) string {
+	return r.capacityReservationGroupVMSize(data, "Standard_D2s_v3")
+}
+
+func (KubernetesClusterResource) capacityReservationGroupVMSize(data acceptance.TestData, vmSize string) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
   features {}
@@ -2541,7 +2633,7 @@ resource "azurerm_kubernetes_cluster" "test" {
   default_node_pool {
     name                          = "default"
     node_count                    = 1
-    vm_size                       = "Standard_D2s_v3"
+    vm_size                       = "%[3]s"
     capacity_reservation_group_id = azurerm_capacity_reservation.test.capacity_reservation_group_id
     upgrade_settings {
       max_surge = "10%%"
@@ -2558,7 +2650,7 @@ resource "azurerm_kubernetes_cluster" "test" {
     azurerm_role_assignment.test
   ]
 }
-`, data.RandomInteger, data.Locations.Primary)
+`, data.RandomInteger, data.Locations.Primary, vmSize)
 }
 
 func (KubernetesClusterResource) completeMaintenanceConfigAutoUpgrade(data acceptance.TestData) string {
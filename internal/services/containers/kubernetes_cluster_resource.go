@@ -24,6 +24,7 @@ import (
 	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-06-02-preview/maintenanceconfigurations"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-06-02-preview/managedclusters"
 	dnsValidate "github.com/hashicorp/go-azure-sdk/resource-manager/dns/2018-05-01/zones"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/kubernetesconfiguration/2022-11-01/extensions"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/operationalinsights/2020-08-01/workspaces"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/privatedns/2020-06-01/privatezones"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -113,6 +114,11 @@ func resourceKubernetesCluster() *pluginsdk.Resource {
 			pluginsdk.ForceNewIfChange("custom_ca_trust_certificates_base64", func(ctx context.Context, old, new, meta interface{}) bool {
 				return len(old.([]interface{})) > 0 && len(new.([]interface{})) == 0
 			}),
+			validateKubernetesClusterVersion,
+			validateKubernetesClusterServiceMeshRevisions,
+			validateKubernetesClusterOpenServiceMesh,
+			validateKubernetesClusterIngressApplicationGatewaySubnetCidr,
+			validateKubernetesClusterAciConnectorLinux,
 		),
 
 		Timeouts: &pluginsdk.ResourceTimeout{
@@ -897,13 +903,13 @@ func resourceKubernetesCluster() *pluginsdk.Resource {
 						"annotations_allowed": {
 							Type:         pluginsdk.TypeString,
 							Optional:     true,
-							ValidateFunc: validation.StringIsNotEmpty,
+							ValidateFunc: containerValidate.KubernetesMonitorMetricsAllowList,
 						},
 
 						"labels_allowed": {
 							Type:         pluginsdk.TypeString,
 							Optional:     true,
-							ValidateFunc: validation.StringIsNotEmpty,
+							ValidateFunc: containerValidate.KubernetesMonitorMetricsAllowList,
 						},
 					},
 				},
@@ -1291,6 +1297,17 @@ func resourceKubernetesCluster() *pluginsdk.Resource {
 							Type:     pluginsdk.TypeBool,
 							Optional: true,
 						},
+						"revisions": {
+							Type:     pluginsdk.TypeList,
+							Optional: true,
+							Computed: true,
+							MinItems: 1,
+							MaxItems: 2,
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+						},
 					},
 				},
 			},
@@ -1714,6 +1731,18 @@ func resourceKubernetesClusterCreate(d *pluginsdk.ResourceData, meta interface{}
 		return fmt.Errorf("expanding `default_node_pool`: %+v", err)
 	}
 
+	if profile := (*agentProfiles)[0]; profile.CapacityReservationGroupID != nil {
+		if err := validateKubernetesNodePoolCapacityReservationGroup(ctx, meta, *profile.CapacityReservationGroupID, pointer.From(profile.VMSize), pointer.From(profile.AvailabilityZones)); err != nil {
+			return fmt.Errorf("`default_node_pool`: %+v", err)
+		}
+	}
+
+	if profile := (*agentProfiles)[0]; profile.HostGroupID != nil {
+		if err := validateKubernetesNodePoolHostGroup(ctx, meta, *profile.HostGroupID, pointer.From(profile.AvailabilityZones)); err != nil {
+			return fmt.Errorf("`default_node_pool`: %+v", err)
+		}
+	}
+
 	// the AKS API will create the default node pool with the same version as the control plane regardless of what is
 	// supplied by the user which will result in a diff in some cases, so if versions have been supplied check that they
 	// are identical
@@ -1982,6 +2011,12 @@ func resourceKubernetesClusterCreate(d *pluginsdk.ResourceData, meta interface{}
 		}
 	}
 
+	if daprRaw, ok := d.GetOk("dapr"); ok {
+		if err := createOrUpdateKubernetesClusterDaprExtension(ctx, meta.(*clients.Client).Containers.KubernetesExtensionsClient, id, daprRaw.([]interface{}), false); err != nil {
+			return err
+		}
+	}
+
 	d.SetId(id.ID())
 	return resourceKubernetesClusterRead(d, meta)
 }
@@ -2095,14 +2130,14 @@ func resourceKubernetesClusterUpdate(d *pluginsdk.ResourceData, meta interface{}
 		}
 	}
 
-	if d.HasChange("aci_connector_linux") || d.HasChange("azure_policy_enabled") || d.HasChange("confidential_computing") || d.HasChange("http_application_routing_enabled") || d.HasChange("oms_agent") || d.HasChange("ingress_application_gateway") || d.HasChange("open_service_mesh_enabled") || d.HasChange("key_vault_secrets_provider") {
+	if d.HasChange("aci_connector_linux") || d.HasChange("azure_policy_enabled") || d.HasChange("azure_policy_version") || d.HasChange("confidential_computing") || d.HasChange("http_application_routing_enabled") || d.HasChange("oms_agent") || d.HasChange("ingress_application_gateway") || d.HasChange("open_service_mesh_enabled") || d.HasChange("key_vault_secrets_provider") {
 		updateCluster = true
 		addOns := collectKubernetesAddons(d)
 		addonProfiles, err := expandKubernetesAddOns(d, addOns, env)
 		if err != nil {
 			return err
 		}
-		existing.Model.Properties.AddonProfiles = addonProfiles
+		existing.Model.Properties.AddonProfiles = mergeKubernetesAddOnProfiles(existing.Model.Properties.AddonProfiles, addonProfiles)
 	}
 
 	if d.HasChange("api_server_authorized_ip_ranges") || d.HasChange("run_command_enabled") || d.HasChange("private_cluster_public_fqdn_enabled") || d.HasChange("api_server_access_profile") {
@@ -2666,6 +2701,13 @@ func resourceKubernetesClusterUpdate(d *pluginsdk.ResourceData, meta interface{}
 		}
 	}
 
+	if d.HasChange("dapr") {
+		oldDapr, newDapr := d.GetChange("dapr")
+		if err := createOrUpdateKubernetesClusterDaprExtension(ctx, containersClient.KubernetesExtensionsClient, *id, newDapr.([]interface{}), len(oldDapr.([]interface{})) > 0); err != nil {
+			return err
+		}
+	}
+
 	d.Partial(false)
 
 	return resourceKubernetesClusterRead(d, meta)
@@ -2805,11 +2847,24 @@ func resourceKubernetesClusterRead(d *pluginsdk.ResourceData, meta interface{})
 
 			if props.AddonProfiles != nil {
 				addOns := flattenKubernetesAddOns(*props.AddonProfiles)
+
+				// on a Kubernetes Cluster using a Managed Identity, an addon which has lost its identity server-side is
+				// removed from the state, so that the addon is shown as changing in the plan - and is re-sent (re-creating
+				// its identity) during the next apply
+				if identity := d.Get("identity").([]interface{}); len(identity) > 0 {
+					for _, addOn := range kubernetesAddOnsWithLostIdentity(collectKubernetesAddons(d), addOns) {
+						log.Printf("[DEBUG] the Managed Identity for the addon %q of %s has been lost, marking the addon for update", addOn, id)
+						addOns[addOn] = []interface{}{}
+					}
+				}
+
 				d.Set("aci_connector_linux", addOns["aci_connector_linux"])
 				d.Set("azure_policy_enabled", addOns["azure_policy_enabled"].(bool))
+				d.Set("azure_policy_version", addOns["azure_policy_version"].(string))
 				d.Set("confidential_computing", addOns["confidential_computing"])
 				d.Set("http_application_routing_enabled", addOns["http_application_routing_enabled"].(bool))
 				d.Set("http_application_routing_zone_name", addOns["http_application_routing_zone_name"])
+				d.Set("http_application_routing_zone_id", kubernetesHttpApplicationRoutingZoneId(id.SubscriptionId, nodeResourceGroup, addOns["http_application_routing_zone_name"].(string)))
 				d.Set("oms_agent", addOns["oms_agent"])
 				d.Set("ingress_application_gateway", addOns["ingress_application_gateway"])
 				d.Set("open_service_mesh_enabled", addOns["open_service_mesh_enabled"].(bool))
@@ -2833,6 +2888,11 @@ func resourceKubernetesClusterRead(d *pluginsdk.ResourceData, meta interface{})
 				return fmt.Errorf("setting `service_mesh_profile`: %+v", err)
 			}
 
+			storageProfile := flattenStorageProfile(props.StorageProfile, d)
+			if err := d.Set("storage_profile", storageProfile); err != nil {
+				return fmt.Errorf("setting `storage_profile`: %+v", err)
+			}
+
 			flattenedDefaultNodePool, err := FlattenDefaultNodePool(props.AgentPoolProfiles, d)
 			if err != nil {
 				return fmt.Errorf("flattening `default_node_pool`: %+v", err)
@@ -2991,6 +3051,19 @@ func resourceKubernetesClusterRead(d *pluginsdk.ResourceData, meta interface{})
 			d.Set("maintenance_window_node_os", flattenKubernetesClusterMaintenanceConfiguration(configurationBody.Properties.MaintenanceWindow))
 		}
 
+		// the Dapr Extension is only read back when it's managed by the `dapr` block, so that an Extension managed
+		// elsewhere (e.g. using the `azurerm_kubernetes_cluster_extension` resource) isn't pulled into the state
+		if v := d.Get("dapr").([]interface{}); len(v) > 0 {
+			daprId := extensions.NewScopedExtensionID(id.ID(), kubernetesClusterDaprExtensionName)
+			daprResp, err := meta.(*clients.Client).Containers.KubernetesExtensionsClient.Get(ctx, daprId)
+			if err != nil && !response.WasNotFound(daprResp.HttpResponse) {
+				// the Extensions API requires the `Microsoft.KubernetesConfiguration` Resource Provider to be registered
+				log.Printf("[DEBUG] retrieving Dapr Extension %s: %+v", daprId, err)
+			} else if err := d.Set("dapr", flattenKubernetesClusterDaprExtension(daprResp.Model)); err != nil {
+				return fmt.Errorf("setting `dapr`: %+v", err)
+			}
+		}
+
 		if err := tags.FlattenAndSet(d, model.Tags); err != nil {
 			return fmt.Errorf("setting `tags`: %+v", err)
 		}
@@ -4566,6 +4639,55 @@ func expandStorageProfile(input []interface{}) *managedclusters.ManagedClusterSt
 	return &profile
 }
 
+func flattenStorageProfile(input *managedclusters.ManagedClusterStorageProfile, d *pluginsdk.ResourceData) []interface{} {
+	storageProfile := make([]interface{}, 0)
+	if input == nil {
+		return storageProfile
+	}
+
+	blobEnabled := false
+	if input.BlobCSIDriver != nil && input.BlobCSIDriver.Enabled != nil {
+		blobEnabled = *input.BlobCSIDriver.Enabled
+	}
+
+	diskEnabled := true
+	if input.DiskCSIDriver != nil && input.DiskCSIDriver.Enabled != nil {
+		diskEnabled = *input.DiskCSIDriver.Enabled
+	}
+
+	diskVersion := "v1"
+	if input.DiskCSIDriver != nil && input.DiskCSIDriver.Version != nil && *input.DiskCSIDriver.Version != "" {
+		diskVersion = *input.DiskCSIDriver.Version
+	}
+
+	fileEnabled := true
+	if input.FileCSIDriver != nil && input.FileCSIDriver.Enabled != nil {
+		fileEnabled = *input.FileCSIDriver.Enabled
+	}
+
+	snapshotController := true
+	if input.SnapshotController != nil && input.SnapshotController.Enabled != nil {
+		snapshotController = *input.SnapshotController.Enabled
+	}
+
+	// Azure enables the Disk and File CSI Drivers and the Snapshot Controller by default, as such when the
+	// `storage_profile` block is omitted and these defaults are returned there's no drift to surface
+	usesDefaults := !blobEnabled && diskEnabled && strings.EqualFold(diskVersion, "v1") && fileEnabled && snapshotController
+	if usesDefaults && len(d.Get("storage_profile").([]interface{})) == 0 {
+		return storageProfile
+	}
+
+	storageProfile = append(storageProfile, map[string]interface{}{
+		"blob_driver_enabled":         blobEnabled,
+		"disk_driver_enabled":         diskEnabled,
+		"disk_driver_version":         diskVersion,
+		"file_driver_enabled":         fileEnabled,
+		"snapshot_controller_enabled": snapshotController,
+	})
+
+	return storageProfile
+}
+
 func expandEdgeZone(input string) *edgezones.Model {
 	normalized := edgezones.Normalize(input)
 	if normalized == "" {
@@ -4645,6 +4767,10 @@ func expandKubernetesClusterServiceMeshProfile(input []interface{}, existing *ma
 		}
 
 		profile.Istio.Components.IngressGateways = &istioIngressGatewaysList
+
+		if revisions := raw["revisions"].([]interface{}); len(revisions) > 0 {
+			profile.Istio.Revisions = utils.ExpandStringSlice(revisions)
+		}
 	}
 
 	return &profile
@@ -4733,10 +4859,19 @@ func flattenKubernetesClusterAzureServiceMeshProfile(input *managedclusters.Serv
 	}
 
 	returnMap := map[string]interface{}{
-		"mode": string(managedclusters.ServiceMeshModeIstio),
+		"mode":      string(managedclusters.ServiceMeshModeIstio),
+		"revisions": []interface{}{},
+	}
+
+	if input.Istio == nil {
+		return []interface{}{returnMap}
+	}
+
+	if input.Istio.Revisions != nil {
+		returnMap["revisions"] = utils.FlattenStringSlice(input.Istio.Revisions)
 	}
 
-	if (input.Istio.Components.IngressGateways != nil) && len(*input.Istio.Components.IngressGateways) > 0 {
+	if input.Istio.Components != nil && (input.Istio.Components.IngressGateways != nil) && len(*input.Istio.Components.IngressGateways) > 0 {
 
 		for _, value := range *input.Istio.Components.IngressGateways {
 
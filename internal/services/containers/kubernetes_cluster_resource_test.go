@@ -6,6 +6,7 @@ package containers_test
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 	"time"
 
@@ -52,8 +53,22 @@ func TestAccKubernetesCluster_dedicatedHost(t *testing.T) {
 			Config: r.dedicatedHost(data),
 			Check: acceptance.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("default_node_pool.0.host_group_id").MatchesOtherKey(check.That("azurerm_dedicated_host_group.test").Key("id")),
 			),
 		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccKubernetesCluster_dedicatedHostZonesMismatch(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_kubernetes_cluster", "test")
+	r := KubernetesClusterResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.dedicatedHostZonesMismatch(data),
+			ExpectError: regexp.MustCompile("`zones` can't be specified when using a regional Dedicated Host Group"),
+		},
 	})
 }
 
@@ -303,6 +318,46 @@ resource "azurerm_kubernetes_cluster" "test" {
   `, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger, controlPlaneVersion)
 }
 
+func (KubernetesClusterResource) dedicatedHostZonesMismatch(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-aks-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_dedicated_host_group" "test" {
+  name                        = "acctestDHG-compute-%[1]d"
+  resource_group_name         = azurerm_resource_group.test.name
+  location                    = azurerm_resource_group.test.location
+  platform_fault_domain_count = 3
+  automatic_placement_enabled = true
+}
+
+resource "azurerm_kubernetes_cluster" "test" {
+  name                = "acctestaks%[1]d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+  dns_prefix          = "acctestaks%[1]d"
+
+  default_node_pool {
+    name          = "default"
+    node_count    = 1
+    vm_size       = "Standard_D2s_v3"
+    host_group_id = azurerm_dedicated_host_group.test.id
+    zones         = ["1"]
+  }
+
+  identity {
+    type = "SystemAssigned"
+  }
+}
+  `, data.RandomInteger, data.Locations.Primary)
+}
+
 func (KubernetesClusterResource) dedicatedHost(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
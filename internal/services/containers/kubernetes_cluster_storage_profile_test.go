@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package containers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-06-02-preview/managedclusters"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestFlattenStorageProfile(t *testing.T) {
+	azureDefaults := &managedclusters.ManagedClusterStorageProfile{
+		BlobCSIDriver: &managedclusters.ManagedClusterStorageProfileBlobCSIDriver{
+			Enabled: pointer.To(false),
+		},
+		DiskCSIDriver: &managedclusters.ManagedClusterStorageProfileDiskCSIDriver{
+			Enabled: pointer.To(true),
+			Version: pointer.To("v1"),
+		},
+		FileCSIDriver: &managedclusters.ManagedClusterStorageProfileFileCSIDriver{
+			Enabled: pointer.To(true),
+		},
+		SnapshotController: &managedclusters.ManagedClusterStorageProfileSnapshotController{
+			Enabled: pointer.To(true),
+		},
+	}
+
+	defaultsBlock := []interface{}{
+		map[string]interface{}{
+			"blob_driver_enabled":         false,
+			"disk_driver_enabled":         true,
+			"disk_driver_version":         "v1",
+			"file_driver_enabled":         true,
+			"snapshot_controller_enabled": true,
+		},
+	}
+
+	testData := []struct {
+		Name     string
+		Config   map[string]interface{}
+		Input    *managedclusters.ManagedClusterStorageProfile
+		Expected []interface{}
+	}{
+		{
+			Name:     "no storage profile returned",
+			Config:   map[string]interface{}{},
+			Input:    nil,
+			Expected: []interface{}{},
+		},
+		{
+			Name:     "block omitted with the Azure defaults returned",
+			Config:   map[string]interface{}{},
+			Input:    azureDefaults,
+			Expected: []interface{}{},
+		},
+		{
+			Name:   "block omitted with only the enabled drivers returned",
+			Config: map[string]interface{}{},
+			Input: &managedclusters.ManagedClusterStorageProfile{
+				DiskCSIDriver: &managedclusters.ManagedClusterStorageProfileDiskCSIDriver{
+					Enabled: pointer.To(true),
+				},
+				FileCSIDriver: &managedclusters.ManagedClusterStorageProfileFileCSIDriver{
+					Enabled: pointer.To(true),
+				},
+				SnapshotController: &managedclusters.ManagedClusterStorageProfileSnapshotController{
+					Enabled: pointer.To(true),
+				},
+			},
+			Expected: []interface{}{},
+		},
+		{
+			Name: "block configured with the Azure defaults returned",
+			Config: map[string]interface{}{
+				"storage_profile": defaultsBlock,
+			},
+			Input:    azureDefaults,
+			Expected: defaultsBlock,
+		},
+		{
+			Name:   "block omitted with drift from the Azure defaults",
+			Config: map[string]interface{}{},
+			Input: &managedclusters.ManagedClusterStorageProfile{
+				BlobCSIDriver: &managedclusters.ManagedClusterStorageProfileBlobCSIDriver{
+					Enabled: pointer.To(true),
+				},
+				DiskCSIDriver: &managedclusters.ManagedClusterStorageProfileDiskCSIDriver{
+					Enabled: pointer.To(true),
+					Version: pointer.To("v1"),
+				},
+				FileCSIDriver: &managedclusters.ManagedClusterStorageProfileFileCSIDriver{
+					Enabled: pointer.To(false),
+				},
+				SnapshotController: &managedclusters.ManagedClusterStorageProfileSnapshotController{
+					Enabled: pointer.To(true),
+				},
+			},
+			Expected: []interface{}{
+				map[string]interface{}{
+					"blob_driver_enabled":         true,
+					"disk_driver_enabled":         true,
+					"disk_driver_version":         "v1",
+					"file_driver_enabled":         false,
+					"snapshot_controller_enabled": true,
+				},
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		d := schema.TestResourceDataRaw(t, resourceKubernetesCluster().Schema, v.Config)
+		actual := flattenStorageProfile(v.Input, d)
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
@@ -6,19 +6,48 @@ package containers
 import (
 	"context"
 	"fmt"
+	"log"
+	"net"
 	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-helpers/lang/response"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/identity"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2022-03-01/capacityreservationgroups"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2022-03-01/capacityreservations"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2024-03-01/dedicatedhostgroups"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2019-08-01/containerservices"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-06-02-preview/agentpools"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-06-02-preview/managedclusters"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/client"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
 func validateKubernetesCluster(d *pluginsdk.ResourceData, cluster *managedclusters.ManagedCluster, resourceGroup, name string) error {
+	if defaultNodePools := d.Get("default_node_pool").([]interface{}); len(defaultNodePools) > 0 && defaultNodePools[0] != nil {
+		podSubnetId := defaultNodePools[0].(map[string]interface{})["pod_subnet_id"].(string)
+
+		networkPlugin := ""
+		networkPluginMode := ""
+		if profiles := d.Get("network_profile").([]interface{}); len(profiles) > 0 && profiles[0] != nil {
+			profile := profiles[0].(map[string]interface{})
+			networkPlugin = profile["network_plugin"].(string)
+			networkPluginMode = profile["network_plugin_mode"].(string)
+		}
+
+		if err := validateKubernetesClusterPodSubnet(networkPlugin, networkPluginMode, podSubnetId, cluster == nil); err != nil {
+			return fmt.Errorf("`default_node_pool`: %+v", err)
+		}
+	}
+
 	if v, exists := d.GetOk("network_profile"); exists {
 		rawProfiles := v.([]interface{})
 
@@ -267,6 +296,180 @@ details can be found at https://aka.ms/version-skew-policy.
 `, desiredNodePoolVersion, nodePoolName, clusterName, resourceGroup, clusterVersionDetails, versionsList)
 }
 
+// validateKubernetesClusterPodSubnet confirms a Pod Subnet is only used with the Azure CNI network plugin, which
+// allocates Pod IPs from the Subnet - whereas in Overlay mode Pod IPs are allocated from the `pod_cidr` instead.
+// Existing clusters being upgraded from Azure CNI to Overlay mode need to retain the Pod Subnet, so this is only
+// enforced for new clusters.
+func validateKubernetesClusterPodSubnet(networkPlugin, networkPluginMode, podSubnetId string, newCluster bool) error {
+	if podSubnetId == "" {
+		return nil
+	}
+
+	if !strings.EqualFold(networkPlugin, string(managedclusters.NetworkPluginAzure)) {
+		return fmt.Errorf("`pod_subnet_id` can only be specified when `network_profile.0.network_plugin` is set to `azure`")
+	}
+
+	if newCluster && strings.EqualFold(networkPluginMode, string(managedclusters.NetworkPluginModeOverlay)) {
+		return fmt.Errorf("`pod_subnet_id` can't be specified when `network_profile.0.network_plugin_mode` is set to `overlay`, since Pod IPs are allocated from the `pod_cidr`")
+	}
+
+	return nil
+}
+
+// validateKubernetesNodePoolCapacityReservationGroup confirms the Capacity Reservation Group contains a Capacity Reservation
+// which can be used by the Node Pool, since otherwise the Node Pool will fail to provision
+func validateKubernetesNodePoolCapacityReservationGroup(ctx context.Context, meta interface{}, capacityReservationGroupId, vmSize string, zones []string) error {
+	groupsClient := meta.(*clients.Client).Compute.CapacityReservationGroupsClient
+	reservationsClient := meta.(*clients.Client).Compute.CapacityReservationsClient
+
+	groupId, err := capacityreservationgroups.ParseCapacityReservationGroupID(capacityReservationGroupId)
+	if err != nil {
+		return err
+	}
+
+	group, err := groupsClient.Get(ctx, *groupId, capacityreservationgroups.DefaultGetOperationOptions())
+	if err != nil {
+		return fmt.Errorf("retrieving %s: %+v", groupId, err)
+	}
+
+	reservations := make([]capacityreservations.CapacityReservation, 0)
+	if model := group.Model; model != nil && model.Properties != nil && model.Properties.CapacityReservations != nil {
+		for _, item := range *model.Properties.CapacityReservations {
+			if item.Id == nil {
+				continue
+			}
+
+			reservationId, err := capacityreservations.ParseCapacityReservationIDInsensitively(*item.Id)
+			if err != nil {
+				return err
+			}
+
+			reservation, err := reservationsClient.Get(ctx, *reservationId, capacityreservations.DefaultGetOperationOptions())
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", reservationId, err)
+			}
+			if reservation.Model != nil {
+				reservations = append(reservations, *reservation.Model)
+			}
+		}
+	}
+
+	if err := validateCapacityReservationsSupportNodePool(vmSize, zones, reservations); err != nil {
+		return fmt.Errorf("%s: %+v", groupId, err)
+	}
+
+	return nil
+}
+
+// validateCapacityReservationsSupportNodePool confirms there's a Capacity Reservation for the VM Size of the Node Pool in
+// each of its Availability Zones - a zonal Node Pool requires a Capacity Reservation in the same zone, whereas a regional
+// Node Pool requires a regional Capacity Reservation
+func validateCapacityReservationsSupportNodePool(vmSize string, zones []string, reservations []capacityreservations.CapacityReservation) error {
+	reservedZones := make(map[string]bool)
+	hasRegionalReservation := false
+	for _, reservation := range reservations {
+		if !strings.EqualFold(pointer.From(reservation.Sku.Name), vmSize) {
+			continue
+		}
+
+		if reservation.Zones == nil || len(*reservation.Zones) == 0 {
+			hasRegionalReservation = true
+			continue
+		}
+		for _, zone := range *reservation.Zones {
+			reservedZones[zone] = true
+		}
+	}
+
+	if len(zones) == 0 {
+		if !hasRegionalReservation {
+			return fmt.Errorf("no regional Capacity Reservation was found for the VM Size %q - the `vm_size` must match the SKU of a Capacity Reservation which isn't zonal", vmSize)
+		}
+		return nil
+	}
+
+	missing := make([]string, 0)
+	for _, zone := range zones {
+		if !reservedZones[zone] {
+			missing = append(missing, zone)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("no Capacity Reservation was found for the VM Size %q in the zone(s) %s - the `vm_size` and `zones` must match the SKU and zones of the Capacity Reservations", vmSize, strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// validateKubernetesNodePoolHostGroup confirms the Dedicated Host Group can be used by the Node Pool, since otherwise the
+// Node Pool will fail to provision
+func validateKubernetesNodePoolHostGroup(ctx context.Context, meta interface{}, hostGroupId string, zones []string) error {
+	client := meta.(*clients.Client).Compute.DedicatedHostGroupsClient
+
+	groupId, err := commonids.ParseDedicatedHostGroupIDInsensitively(hostGroupId)
+	if err != nil {
+		return err
+	}
+
+	group, err := client.Get(ctx, *groupId, dedicatedhostgroups.DefaultGetOperationOptions())
+	if err != nil {
+		return fmt.Errorf("retrieving %s: %+v", groupId, err)
+	}
+	if group.Model == nil {
+		return fmt.Errorf("retrieving %s: `model` was nil", groupId)
+	}
+
+	if err := validateHostGroupSupportsNodePool(zones, *group.Model); err != nil {
+		return fmt.Errorf("%s: %+v", groupId, err)
+	}
+
+	return nil
+}
+
+// validateHostGroupSupportsNodePool confirms the Dedicated Host Group supports automatic placement (which AKS requires to
+// place the nodes) and that the zones of the Node Pool match those of the Dedicated Host Group - a zonal Dedicated Host
+// Group can only be used by a Node Pool within the same zone(s), whereas a regional one can only be used by a regional Node Pool
+func validateHostGroupSupportsNodePool(zones []string, group dedicatedhostgroups.DedicatedHostGroup) error {
+	if group.Properties == nil || !pointer.From(group.Properties.SupportAutomaticPlacement) {
+		return fmt.Errorf("the Dedicated Host Group must have `automatic_placement_enabled` set to `true` to be used by a Node Pool")
+	}
+
+	groupZones := make([]string, 0)
+	if group.Zones != nil {
+		groupZones = *group.Zones
+	}
+
+	if len(groupZones) == 0 {
+		if len(zones) > 0 {
+			return fmt.Errorf("`zones` can't be specified when using a regional Dedicated Host Group")
+		}
+		return nil
+	}
+
+	if len(zones) == 0 {
+		return fmt.Errorf("`zones` must be set to the zone(s) of the Dedicated Host Group (%s)", strings.Join(groupZones, ", "))
+	}
+
+	missing := make([]string, 0)
+	for _, zone := range zones {
+		found := false
+		for _, groupZone := range groupZones {
+			if zone == groupZone {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, zone)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("the Dedicated Host Group isn't available in the zone(s) %s - `zones` must be within the zone(s) of the Dedicated Host Group (%s)", strings.Join(missing, ", "), strings.Join(groupZones, ", "))
+	}
+
+	return nil
+}
+
 func validateNodePoolSupportsVersion(ctx context.Context, client *client.Client, currentNodePoolVersion string, defaultNodePoolId agentpools.AgentPoolId, desiredNodePoolVersion string) error {
 	// confirm the version being used is >= the version of the control plane
 	clusterId := commonids.NewKubernetesClusterID(defaultNodePoolId.SubscriptionId, defaultNodePoolId.ResourceGroupName, defaultNodePoolId.ManagedClusterName)
@@ -318,3 +521,347 @@ func validateNodePoolSupportsVersion(ctx context.Context, client *client.Client,
 
 	return nil
 }
+
+// validateKubernetesClusterVersion confirms that the desired `kubernetes_version` is available in the target
+// region at plan time. Alias versions (major.minor) are resolved to the latest GA patch version, matching the
+// version AKS will install - which is then surfaced in the plan via `current_kubernetes_version`.
+func validateKubernetesClusterVersion(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("kubernetes_version") || !d.NewValueKnown("kubernetes_version") || !d.NewValueKnown("location") {
+		return nil
+	}
+
+	desiredVersion := d.Get("kubernetes_version").(string)
+	locationName := location.Normalize(d.Get("location").(string))
+	if desiredVersion == "" || locationName == "" {
+		return nil
+	}
+
+	client := meta.(*clients.Client).Containers.ServicesClient
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+
+	id := containerservices.NewLocationID(subscriptionId, locationName)
+	options := containerservices.DefaultListOrchestratorsOperationOptions()
+	options.ResourceType = pointer.To("managedClusters")
+	resp, err := client.ListOrchestrators(ctx, id, options)
+	if err != nil {
+		// this is trying to be helpful, the API will reject an unsupported version during the apply regardless
+		log.Printf("[DEBUG] unable to retrieve the Kubernetes Versions available in %q, skipping version validation: %+v", id.LocationName, err)
+		return nil
+	}
+
+	var orchestrators []containerservices.OrchestratorVersionProfile
+	if model := resp.Model; model != nil {
+		orchestrators = model.Properties.Orchestrators
+	}
+
+	resolvedVersion, err := resolveKubernetesVersion(desiredVersion, locationName, orchestrators)
+	if err != nil {
+		return err
+	}
+
+	if *resolvedVersion != desiredVersion {
+		log.Printf("[DEBUG] Kubernetes Version %q resolves to %q in %q", desiredVersion, *resolvedVersion, locationName)
+	}
+
+	return d.SetNewComputed("current_kubernetes_version")
+}
+
+// resolveKubernetesVersion returns the Kubernetes Version which will be deployed for the desired version - this is
+// the desired version itself when an exact match exists, otherwise the latest GA patch version for an alias version.
+func resolveKubernetesVersion(desiredVersion, locationName string, orchestrators []containerservices.OrchestratorVersionProfile) (*string, error) {
+	isAlias := strings.Count(desiredVersion, ".") == 1
+
+	var resolved *version.Version
+	availableVersions := make([]string, 0)
+	for _, orchestrator := range orchestrators {
+		if !strings.EqualFold(orchestrator.OrchestratorType, "Kubernetes") {
+			continue
+		}
+
+		v := orchestrator.OrchestratorVersion
+		availableVersions = append(availableVersions, v)
+		if v == desiredVersion {
+			return pointer.To(v), nil
+		}
+
+		// AKS only resolves alias versions to GA patch versions
+		if !isAlias || pointer.From(orchestrator.IsPreview) || !strings.HasPrefix(v, desiredVersion+".") {
+			continue
+		}
+
+		candidate, err := version.NewVersion(v)
+		if err != nil {
+			log.Printf("[WARN] Cannot parse orchestrator version %q - skipping: %s", v, err)
+			continue
+		}
+		if resolved == nil || candidate.GreaterThan(resolved) {
+			resolved = candidate
+		}
+	}
+
+	if resolved == nil {
+		return nil, kubernetesVersionNotAvailableError(desiredVersion, locationName, availableVersions)
+	}
+
+	return pointer.To(resolved.Original()), nil
+}
+
+// returned when the desired Kubernetes Version (or alias version) doesn't match any version available in the region
+var kubernetesVersionNotAvailableError = func(desiredVersion, locationName string, availableVersions []string) error {
+	sort.Strings(availableVersions)
+	versions := make([]string, 0)
+	for _, v := range availableVersions {
+		versions = append(versions, fmt.Sprintf(" * %s", v))
+	}
+
+	return fmt.Errorf(`
+The Kubernetes Version %q is not available in %q.
+
+A Kubernetes Version can either be specified as a full version (e.g. "1.28.5") or as an alias
+version (e.g. "1.28"), in which case the latest GA patch version for that minor version is used.
+
+The Kubernetes Versions available in this region are:
+%s
+`, desiredVersion, locationName, strings.Join(versions, "\n"))
+}
+
+// validateKubernetesClusterAciConnectorLinux confirms the `aci_connector_linux` addon is only used with Azure CNI, since
+// otherwise the cluster fails to provision the Virtual Nodes - which is surfaced late during the apply
+func validateKubernetesClusterAciConnectorLinux(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("aci_connector_linux") || !d.NewValueKnown("network_profile") {
+		return nil
+	}
+
+	aciConnectorLinux := d.Get("aci_connector_linux").([]interface{})
+	return validateKubernetesClusterAciConnectorNetworkPlugin(len(aciConnectorLinux) > 0, d.Get("network_profile.0.network_plugin").(string))
+}
+
+func validateKubernetesClusterAciConnectorNetworkPlugin(aciConnectorLinuxEnabled bool, networkPlugin string) error {
+	if !aciConnectorLinuxEnabled {
+		return nil
+	}
+
+	// when the `network_profile` block is omitted the cluster uses kubenet
+	if networkPlugin == "" {
+		networkPlugin = string(managedclusters.NetworkPluginKubenet)
+	}
+
+	if !strings.EqualFold(networkPlugin, string(managedclusters.NetworkPluginAzure)) {
+		return fmt.Errorf("the `aci_connector_linux` addon requires Azure CNI, since the Virtual Nodes are deployed into the `subnet_name` within the cluster's Virtual Network - `network_profile.0.network_plugin` must be set to `azure` but got %q", networkPlugin)
+	}
+
+	return nil
+}
+
+// validateKubernetesClusterIngressApplicationGatewaySubnetCidr ensures the `subnet_cidr` used to create the Application
+// Gateway for the `ingress_application_gateway` addon doesn't overlap the Service or Pod CIDRs of the cluster, which
+// otherwise fails when the addon is provisioned rather than at plan time
+func validateKubernetesClusterIngressApplicationGatewaySubnetCidr(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("ingress_application_gateway.0.subnet_cidr") {
+		return nil
+	}
+
+	subnetCidr := d.Get("ingress_application_gateway.0.subnet_cidr").(string)
+	if subnetCidr == "" {
+		return nil
+	}
+
+	clusterCidrs := make(map[string][]string)
+	for _, key := range []string{"pod_cidr", "service_cidr"} {
+		field := fmt.Sprintf("network_profile.0.%s", key)
+		if v := d.Get(field).(string); v != "" && d.NewValueKnown(field) {
+			clusterCidrs[key] = append(clusterCidrs[key], v)
+		}
+	}
+	for _, key := range []string{"pod_cidrs", "service_cidrs"} {
+		field := fmt.Sprintf("network_profile.0.%s", key)
+		if !d.NewValueKnown(field) {
+			continue
+		}
+		for _, v := range d.Get(field).([]interface{}) {
+			if v != nil && v.(string) != "" {
+				clusterCidrs[key] = append(clusterCidrs[key], v.(string))
+			}
+		}
+	}
+
+	return validateKubernetesClusterIngressApplicationGatewaySubnetCidrOverlap(subnetCidr, clusterCidrs)
+}
+
+func validateKubernetesClusterIngressApplicationGatewaySubnetCidrOverlap(subnetCidr string, clusterCidrs map[string][]string) error {
+	_, subnet, err := net.ParseCIDR(subnetCidr)
+	if err != nil {
+		// this is validated by the schema
+		return nil
+	}
+
+	keys := make([]string, 0)
+	for key := range clusterCidrs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		for _, clusterCidr := range clusterCidrs[key] {
+			_, cluster, err := net.ParseCIDR(clusterCidr)
+			if err != nil {
+				continue
+			}
+
+			if subnet.Contains(cluster.IP) || cluster.Contains(subnet.IP) {
+				return fmt.Errorf("the `subnet_cidr` %q of the `ingress_application_gateway` addon overlaps the `network_profile.0.%s` %q of the Kubernetes Cluster - a `subnet_cidr` which doesn't overlap the Service or Pod CIDRs must be used", subnetCidr, key, clusterCidr)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateKubernetesClusterOpenServiceMesh surfaces the Open Service Mesh addon being enabled alongside the Istio-based
+// Service Mesh at plan time, which happens when migrating from OSM to Istio without disabling OSM in the configuration
+func validateKubernetesClusterOpenServiceMesh(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("open_service_mesh_enabled") || !d.NewValueKnown("service_mesh_profile") {
+		return nil
+	}
+
+	serviceMeshProfile := d.Get("service_mesh_profile").([]interface{})
+	return validateKubernetesClusterOpenServiceMeshAndServiceMeshProfile(d.Get("open_service_mesh_enabled").(bool), len(serviceMeshProfile) > 0)
+}
+
+func validateKubernetesClusterOpenServiceMeshAndServiceMeshProfile(openServiceMeshEnabled bool, serviceMeshProfileEnabled bool) error {
+	if openServiceMeshEnabled && serviceMeshProfileEnabled {
+		return fmt.Errorf("the Open Service Mesh addon can't be enabled alongside the Istio-based Service Mesh - `open_service_mesh_enabled` must be set to `false` (or omitted) when a `service_mesh_profile` block is specified")
+	}
+
+	return nil
+}
+
+var kubernetesServiceMeshRevisionRegex = regexp.MustCompile(`^asm-(\d+)-(\d+)$`)
+
+// validateKubernetesClusterServiceMeshRevisions confirms that a change to the `revisions` of the Service Mesh follows
+// the canary upgrade process, where the new revision is added alongside the existing revision, the workloads are
+// migrated, and then either the previous revision (completing the upgrade) or the new revision (rolling back) is removed
+func validateKubernetesClusterServiceMeshRevisions(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	key := "service_mesh_profile.0.revisions"
+	if !d.HasChange(key) || !d.NewValueKnown(key) {
+		return nil
+	}
+
+	oldRaw, newRaw := d.GetChange(key)
+	old := make([]string, 0)
+	for _, v := range oldRaw.([]interface{}) {
+		old = append(old, v.(string))
+	}
+	new := make([]string, 0)
+	for _, v := range newRaw.([]interface{}) {
+		new = append(new, v.(string))
+	}
+
+	return validateKubernetesServiceMeshRevisionTransition(old, new)
+}
+
+func validateKubernetesServiceMeshRevisionTransition(old, new []string) error {
+	// the Service Mesh is being disabled
+	if len(new) == 0 {
+		return nil
+	}
+
+	for _, revision := range new {
+		if _, _, err := parseKubernetesServiceMeshRevision(revision); err != nil {
+			return err
+		}
+	}
+	if len(new) == 2 && strings.EqualFold(new[0], new[1]) {
+		return fmt.Errorf("the `revisions` of the Service Mesh must be unique but got %q twice", new[0])
+	}
+
+	// the Service Mesh is being enabled
+	if len(old) == 0 {
+		if len(new) > 1 {
+			return fmt.Errorf("only a single revision can be specified when enabling the Service Mesh, a canary upgrade can be started once the Service Mesh has been enabled")
+		}
+		return nil
+	}
+
+	contains := func(input []string, revision string) bool {
+		for _, v := range input {
+			if strings.EqualFold(v, revision) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case len(old) == 1 && len(new) == 1:
+		if !strings.EqualFold(old[0], new[0]) {
+			return fmt.Errorf("the Service Mesh must be upgraded from %[1]q to %[2]q using a canary upgrade - add %[2]q alongside %[1]q, migrate the workloads and then remove %[1]q", old[0], new[0])
+		}
+
+	case len(old) == 1 && len(new) == 2:
+		// starting a canary upgrade - the existing revision must be kept and the new revision must be newer
+		if !contains(new, old[0]) {
+			return fmt.Errorf("a canary upgrade of the Service Mesh must keep the existing revision %q alongside the new revision", old[0])
+		}
+
+		upgrade := new[0]
+		if strings.EqualFold(upgrade, old[0]) {
+			upgrade = new[1]
+		}
+		newer, err := kubernetesServiceMeshRevisionIsNewer(upgrade, old[0])
+		if err != nil {
+			return err
+		}
+		if !newer {
+			return fmt.Errorf("the revision %q must be newer than the existing revision %q to start a canary upgrade of the Service Mesh", upgrade, old[0])
+		}
+
+	case len(old) == 2 && len(new) == 1:
+		// completing or rolling back a canary upgrade
+		if !contains(old, new[0]) {
+			return fmt.Errorf("a canary upgrade of the Service Mesh from %q to %q is in progress - it must be completed or rolled back by removing one of these revisions, but got %q", old[0], old[1], new[0])
+		}
+
+	case len(old) == 2 && len(new) == 2:
+		if !contains(old, new[0]) || !contains(old, new[1]) {
+			return fmt.Errorf("a canary upgrade of the Service Mesh from %q to %q is in progress - it must be completed or rolled back by removing one of these revisions before another canary upgrade can be started", old[0], old[1])
+		}
+	}
+
+	return nil
+}
+
+// parseKubernetesServiceMeshRevision parses a Service Mesh revision in the format `asm-{major}-{minor}`
+func parseKubernetesServiceMeshRevision(input string) (int, int, error) {
+	matches := kubernetesServiceMeshRevisionRegex.FindStringSubmatch(input)
+	if len(matches) != 3 {
+		return 0, 0, fmt.Errorf("expected the Service Mesh revision %q to be in the format `asm-{major}-{minor}`", input)
+	}
+
+	major, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("parsing the major version of the Service Mesh revision %q: %+v", input, err)
+	}
+	minor, err := strconv.Atoi(matches[2])
+	if err != nil {
+		return 0, 0, fmt.Errorf("parsing the minor version of the Service Mesh revision %q: %+v", input, err)
+	}
+
+	return major, minor, nil
+}
+
+func kubernetesServiceMeshRevisionIsNewer(revision, existing string) (bool, error) {
+	major, minor, err := parseKubernetesServiceMeshRevision(revision)
+	if err != nil {
+		return false, err
+	}
+	existingMajor, existingMinor, err := parseKubernetesServiceMeshRevision(existing)
+	if err != nil {
+		return false, err
+	}
+
+	if major != existingMajor {
+		return major > existingMajor, nil
+	}
+	return minor > existingMinor, nil
+}
@@ -0,0 +1,587 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package containers
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/zones"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2022-03-01/capacityreservations"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2024-03-01/dedicatedhostgroups"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2019-08-01/containerservices"
+)
+
+func TestResolveKubernetesVersion(t *testing.T) {
+	orchestrators := []containerservices.OrchestratorVersionProfile{
+		{OrchestratorType: "Kubernetes", OrchestratorVersion: "1.27.7"},
+		{OrchestratorType: "Kubernetes", OrchestratorVersion: "1.28.3"},
+		{OrchestratorType: "Kubernetes", OrchestratorVersion: "1.28.10"},
+		{OrchestratorType: "Kubernetes", OrchestratorVersion: "1.28.5"},
+		{OrchestratorType: "Kubernetes", OrchestratorVersion: "1.29.0", IsPreview: pointer.To(true)},
+		{OrchestratorType: "Kubernetes", OrchestratorVersion: "1.29.1", IsPreview: pointer.To(true)},
+		{OrchestratorType: "DockerCE", OrchestratorVersion: "1.30.1"},
+	}
+
+	testData := []struct {
+		Input    string
+		Expected *string
+	}{
+		{
+			// exact match
+			Input:    "1.28.5",
+			Expected: pointer.To("1.28.5"),
+		},
+		{
+			// exact match on a preview version
+			Input:    "1.29.1",
+			Expected: pointer.To("1.29.1"),
+		},
+		{
+			// alias resolves to the latest patch version, compared numerically
+			Input:    "1.28",
+			Expected: pointer.To("1.28.10"),
+		},
+		{
+			Input:    "1.27",
+			Expected: pointer.To("1.27.7"),
+		},
+		{
+			// alias versions only resolve to GA patch versions
+			Input:    "1.29",
+			Expected: nil,
+		},
+		{
+			// not a prefix match on the minor version
+			Input:    "1.2",
+			Expected: nil,
+		},
+		{
+			// patch version not available
+			Input:    "1.28.4",
+			Expected: nil,
+		},
+		{
+			// only Kubernetes orchestrators are considered
+			Input:    "1.30",
+			Expected: nil,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		actual, err := resolveKubernetesVersion(v.Input, "westeurope", orchestrators)
+		if err != nil {
+			if v.Expected == nil {
+				continue
+			}
+
+			t.Fatalf("Expected %q to resolve to %q but got an error: %+v", v.Input, *v.Expected, err)
+		}
+
+		if v.Expected == nil {
+			t.Fatalf("Expected an error for %q but got %q", v.Input, *actual)
+		}
+
+		if *actual != *v.Expected {
+			t.Fatalf("Expected %q to resolve to %q but got %q", v.Input, *v.Expected, *actual)
+		}
+	}
+}
+
+func TestValidateKubernetesServiceMeshRevisionTransition(t *testing.T) {
+	testData := []struct {
+		Name        string
+		Old         []string
+		New         []string
+		ShouldError bool
+	}{
+		{
+			Name: "enabling with a single revision",
+			Old:  []string{},
+			New:  []string{"asm-1-17"},
+		},
+		{
+			Name:        "enabling with multiple revisions",
+			Old:         []string{},
+			New:         []string{"asm-1-17", "asm-1-18"},
+			ShouldError: true,
+		},
+		{
+			Name:        "invalid revision",
+			Old:         []string{},
+			New:         []string{"1.17"},
+			ShouldError: true,
+		},
+		{
+			Name: "disabling",
+			Old:  []string{"asm-1-17"},
+			New:  []string{},
+		},
+		{
+			Name: "unchanged",
+			Old:  []string{"asm-1-17", "asm-1-18"},
+			New:  []string{"asm-1-18", "asm-1-17"},
+		},
+		{
+			Name: "starting a canary upgrade",
+			Old:  []string{"asm-1-17"},
+			New:  []string{"asm-1-17", "asm-1-18"},
+		},
+		{
+			Name: "starting a canary upgrade with the new revision first",
+			Old:  []string{"asm-1-17"},
+			New:  []string{"asm-1-18", "asm-1-17"},
+		},
+		{
+			Name: "starting a canary upgrade to a new major version",
+			Old:  []string{"asm-1-18"},
+			New:  []string{"asm-1-18", "asm-2-0"},
+		},
+		{
+			Name:        "starting a canary upgrade to an older revision",
+			Old:         []string{"asm-1-18"},
+			New:         []string{"asm-1-18", "asm-1-17"},
+			ShouldError: true,
+		},
+		{
+			Name:        "starting a canary upgrade with a duplicate revision",
+			Old:         []string{"asm-1-17"},
+			New:         []string{"asm-1-17", "asm-1-17"},
+			ShouldError: true,
+		},
+		{
+			Name:        "starting a canary upgrade without the existing revision",
+			Old:         []string{"asm-1-17"},
+			New:         []string{"asm-1-18", "asm-1-19"},
+			ShouldError: true,
+		},
+		{
+			Name:        "replacing the revision without a canary upgrade",
+			Old:         []string{"asm-1-17"},
+			New:         []string{"asm-1-18"},
+			ShouldError: true,
+		},
+		{
+			Name: "completing a canary upgrade",
+			Old:  []string{"asm-1-17", "asm-1-18"},
+			New:  []string{"asm-1-18"},
+		},
+		{
+			Name: "rolling back a canary upgrade",
+			Old:  []string{"asm-1-17", "asm-1-18"},
+			New:  []string{"asm-1-17"},
+		},
+		{
+			Name:        "replacing the revisions during a canary upgrade",
+			Old:         []string{"asm-1-17", "asm-1-18"},
+			New:         []string{"asm-1-19"},
+			ShouldError: true,
+		},
+		{
+			Name:        "starting another canary upgrade during a canary upgrade",
+			Old:         []string{"asm-1-17", "asm-1-18"},
+			New:         []string{"asm-1-18", "asm-1-19"},
+			ShouldError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		err := validateKubernetesServiceMeshRevisionTransition(v.Old, v.New)
+		if v.ShouldError && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !v.ShouldError && err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+	}
+}
+
+func TestValidateKubernetesClusterPodSubnet(t *testing.T) {
+	podSubnetId := "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resources/providers/Microsoft.Network/virtualNetworks/example-network/subnets/pods"
+
+	testData := []struct {
+		Name              string
+		NetworkPlugin     string
+		NetworkPluginMode string
+		PodSubnetId       string
+		Existing          bool
+		ShouldError       bool
+	}{
+		{
+			Name:          "no pod subnet with kubenet",
+			NetworkPlugin: "kubenet",
+		},
+		{
+			Name:          "pod subnet with azure",
+			NetworkPlugin: "azure",
+			PodSubnetId:   podSubnetId,
+		},
+		{
+			Name:              "no pod subnet with azure overlay",
+			NetworkPlugin:     "azure",
+			NetworkPluginMode: "overlay",
+		},
+		{
+			Name:              "pod subnet with azure overlay",
+			NetworkPlugin:     "azure",
+			NetworkPluginMode: "overlay",
+			PodSubnetId:       podSubnetId,
+			ShouldError:       true,
+		},
+		{
+			Name:              "pod subnet when upgrading an existing cluster to azure overlay",
+			NetworkPlugin:     "azure",
+			NetworkPluginMode: "overlay",
+			PodSubnetId:       podSubnetId,
+			Existing:          true,
+		},
+		{
+			Name:          "pod subnet with kubenet",
+			NetworkPlugin: "kubenet",
+			PodSubnetId:   podSubnetId,
+			ShouldError:   true,
+		},
+		{
+			Name:        "pod subnet without a network profile",
+			PodSubnetId: podSubnetId,
+			ShouldError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		err := validateKubernetesClusterPodSubnet(v.NetworkPlugin, v.NetworkPluginMode, v.PodSubnetId, !v.Existing)
+		if v.ShouldError && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !v.ShouldError && err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+	}
+}
+
+func TestValidateCapacityReservationsSupportNodePool(t *testing.T) {
+	reservation := func(sku string, reservationZones ...string) capacityreservations.CapacityReservation {
+		result := capacityreservations.CapacityReservation{
+			Sku: capacityreservations.Sku{
+				Name: pointer.To(sku),
+			},
+		}
+		if len(reservationZones) > 0 {
+			result.Zones = pointer.To(zones.Schema(reservationZones))
+		}
+		return result
+	}
+
+	testData := []struct {
+		Name         string
+		VMSize       string
+		Zones        []string
+		Reservations []capacityreservations.CapacityReservation
+		ShouldError  bool
+	}{
+		{
+			Name:         "regional node pool with a regional reservation",
+			VMSize:       "Standard_D2s_v3",
+			Reservations: []capacityreservations.CapacityReservation{reservation("Standard_D2s_v3")},
+		},
+		{
+			Name:         "regional node pool with a differently cased reservation",
+			VMSize:       "standard_d2s_v3",
+			Reservations: []capacityreservations.CapacityReservation{reservation("Standard_D2s_v3")},
+		},
+		{
+			Name:         "regional node pool with a reservation for another VM size",
+			VMSize:       "Standard_D4s_v3",
+			Reservations: []capacityreservations.CapacityReservation{reservation("Standard_D2s_v3")},
+			ShouldError:  true,
+		},
+		{
+			Name:         "regional node pool with only zonal reservations",
+			VMSize:       "Standard_D2s_v3",
+			Reservations: []capacityreservations.CapacityReservation{reservation("Standard_D2s_v3", "1")},
+			ShouldError:  true,
+		},
+		{
+			Name:         "no reservations",
+			VMSize:       "Standard_D2s_v3",
+			Reservations: []capacityreservations.CapacityReservation{},
+			ShouldError:  true,
+		},
+		{
+			Name:   "zonal node pool with reservations in each zone",
+			VMSize: "Standard_D2s_v3",
+			Zones:  []string{"1", "2"},
+			Reservations: []capacityreservations.CapacityReservation{
+				reservation("Standard_D2s_v3", "1"),
+				reservation("Standard_D2s_v3", "2"),
+			},
+		},
+		{
+			Name:   "zonal node pool missing a zone",
+			VMSize: "Standard_D2s_v3",
+			Zones:  []string{"1", "2"},
+			Reservations: []capacityreservations.CapacityReservation{
+				reservation("Standard_D2s_v3", "1"),
+				reservation("Standard_D4s_v3", "2"),
+			},
+			ShouldError: true,
+		},
+		{
+			Name:         "zonal node pool with a regional reservation",
+			VMSize:       "Standard_D2s_v3",
+			Zones:        []string{"1"},
+			Reservations: []capacityreservations.CapacityReservation{reservation("Standard_D2s_v3")},
+			ShouldError:  true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		err := validateCapacityReservationsSupportNodePool(v.VMSize, v.Zones, v.Reservations)
+		if v.ShouldError && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !v.ShouldError && err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+	}
+}
+
+func TestValidateHostGroupSupportsNodePool(t *testing.T) {
+	hostGroup := func(automaticPlacement bool, groupZones ...string) dedicatedhostgroups.DedicatedHostGroup {
+		result := dedicatedhostgroups.DedicatedHostGroup{
+			Properties: &dedicatedhostgroups.DedicatedHostGroupProperties{
+				PlatformFaultDomainCount:  1,
+				SupportAutomaticPlacement: pointer.To(automaticPlacement),
+			},
+		}
+		if len(groupZones) > 0 {
+			result.Zones = pointer.To(zones.Schema(groupZones))
+		}
+		return result
+	}
+
+	testData := []struct {
+		Name        string
+		Zones       []string
+		HostGroup   dedicatedhostgroups.DedicatedHostGroup
+		ShouldError bool
+	}{
+		{
+			Name:      "regional node pool with a regional host group",
+			HostGroup: hostGroup(true),
+		},
+		{
+			Name:        "automatic placement disabled",
+			HostGroup:   hostGroup(false),
+			ShouldError: true,
+		},
+		{
+			Name:        "no properties",
+			HostGroup:   dedicatedhostgroups.DedicatedHostGroup{},
+			ShouldError: true,
+		},
+		{
+			Name:        "zonal node pool with a regional host group",
+			Zones:       []string{"1"},
+			HostGroup:   hostGroup(true),
+			ShouldError: true,
+		},
+		{
+			Name:        "regional node pool with a zonal host group",
+			HostGroup:   hostGroup(true, "1"),
+			ShouldError: true,
+		},
+		{
+			Name:      "zonal node pool within the zone of the host group",
+			Zones:     []string{"1"},
+			HostGroup: hostGroup(true, "1"),
+		},
+		{
+			Name:        "zonal node pool outside the zone of the host group",
+			Zones:       []string{"1", "2"},
+			HostGroup:   hostGroup(true, "1"),
+			ShouldError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		err := validateHostGroupSupportsNodePool(v.Zones, v.HostGroup)
+		if v.ShouldError && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !v.ShouldError && err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+	}
+}
+
+func TestValidateKubernetesClusterAciConnectorNetworkPlugin(t *testing.T) {
+	testData := []struct {
+		Name                     string
+		AciConnectorLinuxEnabled bool
+		NetworkPlugin            string
+		ShouldError              bool
+	}{
+		{
+			Name:          "addon disabled with kubenet",
+			NetworkPlugin: "kubenet",
+		},
+		{
+			Name:                     "addon enabled with azure",
+			AciConnectorLinuxEnabled: true,
+			NetworkPlugin:            "azure",
+		},
+		{
+			Name:                     "addon enabled with kubenet",
+			AciConnectorLinuxEnabled: true,
+			NetworkPlugin:            "kubenet",
+			ShouldError:              true,
+		},
+		{
+			Name:                     "addon enabled with no network plugin",
+			AciConnectorLinuxEnabled: true,
+			NetworkPlugin:            "none",
+			ShouldError:              true,
+		},
+		{
+			Name:                     "addon enabled without a network profile",
+			AciConnectorLinuxEnabled: true,
+			ShouldError:              true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		err := validateKubernetesClusterAciConnectorNetworkPlugin(v.AciConnectorLinuxEnabled, v.NetworkPlugin)
+		if v.ShouldError && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !v.ShouldError && err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+	}
+}
+
+func TestValidateKubernetesClusterOpenServiceMeshAndServiceMeshProfile(t *testing.T) {
+	testData := []struct {
+		Name                      string
+		OpenServiceMeshEnabled    bool
+		ServiceMeshProfileEnabled bool
+		ShouldError               bool
+	}{
+		{
+			Name: "neither enabled",
+		},
+		{
+			Name:                   "open service mesh enabled",
+			OpenServiceMeshEnabled: true,
+		},
+		{
+			Name:                      "istio enabled",
+			ServiceMeshProfileEnabled: true,
+		},
+		{
+			Name:                      "both enabled",
+			OpenServiceMeshEnabled:    true,
+			ServiceMeshProfileEnabled: true,
+			ShouldError:               true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		err := validateKubernetesClusterOpenServiceMeshAndServiceMeshProfile(v.OpenServiceMeshEnabled, v.ServiceMeshProfileEnabled)
+		if v.ShouldError && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !v.ShouldError && err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+	}
+}
+
+func TestValidateKubernetesClusterIngressApplicationGatewaySubnetCidrOverlap(t *testing.T) {
+	testData := []struct {
+		Name         string
+		SubnetCidr   string
+		ClusterCidrs map[string][]string
+		ShouldError  bool
+	}{
+		{
+			Name:       "no cluster cidrs",
+			SubnetCidr: "10.225.0.0/16",
+		},
+		{
+			Name:       "distinct cidrs",
+			SubnetCidr: "10.225.0.0/16",
+			ClusterCidrs: map[string][]string{
+				"pod_cidr":     {"10.244.0.0/16"},
+				"service_cidr": {"10.0.0.0/16"},
+			},
+		},
+		{
+			Name:       "same as the service cidr",
+			SubnetCidr: "10.0.0.0/16",
+			ClusterCidrs: map[string][]string{
+				"service_cidr": {"10.0.0.0/16"},
+			},
+			ShouldError: true,
+		},
+		{
+			Name:       "within the pod cidr",
+			SubnetCidr: "10.244.10.0/24",
+			ClusterCidrs: map[string][]string{
+				"pod_cidr": {"10.244.0.0/16"},
+			},
+			ShouldError: true,
+		},
+		{
+			Name:       "containing the service cidr",
+			SubnetCidr: "10.0.0.0/8",
+			ClusterCidrs: map[string][]string{
+				"service_cidr": {"10.0.0.0/16"},
+			},
+			ShouldError: true,
+		},
+		{
+			Name:       "overlapping one of the service cidrs",
+			SubnetCidr: "fd00:10::/64",
+			ClusterCidrs: map[string][]string{
+				"service_cidrs": {"10.0.0.0/16", "fd00:10::/108"},
+			},
+			ShouldError: true,
+		},
+		{
+			Name:       "adjacent to the pod cidr",
+			SubnetCidr: "10.245.0.0/16",
+			ClusterCidrs: map[string][]string{
+				"pod_cidr": {"10.244.0.0/16"},
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		err := validateKubernetesClusterIngressApplicationGatewaySubnetCidrOverlap(v.SubnetCidr, v.ClusterCidrs)
+		if v.ShouldError && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !v.ShouldError && err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+	}
+}
@@ -208,8 +208,6 @@ func SchemaDefaultNodePool() *pluginsdk.Schema {
 						ValidateFunc: validation.StringInSlice([]string{
 							string(agentpools.OSSKUAzureLinux),
 							string(agentpools.OSSKUUbuntu),
-							string(agentpools.OSSKUWindowsTwoZeroOneNine),
-							string(agentpools.OSSKUWindowsTwoZeroTwoTwo),
 						}, false),
 					},
 
@@ -263,10 +261,11 @@ func SchemaDefaultNodePool() *pluginsdk.Schema {
 					},
 
 					"host_group_id": {
-						Type:         pluginsdk.TypeString,
-						Optional:     true,
-						ForceNew:     true,
-						ValidateFunc: computeValidate.HostGroupID,
+						Type:          pluginsdk.TypeString,
+						Optional:      true,
+						ForceNew:      true,
+						ValidateFunc:  computeValidate.HostGroupID,
+						ConflictsWith: []string{"default_node_pool.0.capacity_reservation_group_id"},
 					},
 
 					"upgrade_settings": upgradeSettingsSchema(),
@@ -1072,29 +1071,31 @@ func ConvertDefaultNodePoolToAgentPool(input *[]managedclusters.ManagedClusterAg
 	agentpool := agentpools.AgentPool{
 		Name: &defaultCluster.Name,
 		Properties: &agentpools.ManagedClusterAgentPoolProfileProperties{
-			Count:                     defaultCluster.Count,
-			VMSize:                    defaultCluster.VMSize,
-			OsDiskSizeGB:              defaultCluster.OsDiskSizeGB,
-			VnetSubnetID:              defaultCluster.VnetSubnetID,
-			MaxPods:                   defaultCluster.MaxPods,
-			MaxCount:                  defaultCluster.MaxCount,
-			MessageOfTheDay:           defaultCluster.MessageOfTheDay,
-			MinCount:                  defaultCluster.MinCount,
-			EnableAutoScaling:         defaultCluster.EnableAutoScaling,
-			EnableCustomCATrust:       defaultCluster.EnableCustomCATrust,
-			EnableEncryptionAtHost:    defaultCluster.EnableEncryptionAtHost,
-			EnableFIPS:                defaultCluster.EnableFIPS,
-			EnableUltraSSD:            defaultCluster.EnableUltraSSD,
-			OrchestratorVersion:       defaultCluster.OrchestratorVersion,
-			ProximityPlacementGroupID: defaultCluster.ProximityPlacementGroupID,
-			AvailabilityZones:         defaultCluster.AvailabilityZones,
-			EnableNodePublicIP:        defaultCluster.EnableNodePublicIP,
-			NodePublicIPPrefixID:      defaultCluster.NodePublicIPPrefixID,
-			SpotMaxPrice:              defaultCluster.SpotMaxPrice,
-			NodeLabels:                defaultCluster.NodeLabels,
-			NodeTaints:                defaultCluster.NodeTaints,
-			PodSubnetID:               defaultCluster.PodSubnetID,
-			Tags:                      defaultCluster.Tags,
+			Count:                      defaultCluster.Count,
+			VMSize:                     defaultCluster.VMSize,
+			OsDiskSizeGB:               defaultCluster.OsDiskSizeGB,
+			VnetSubnetID:               defaultCluster.VnetSubnetID,
+			MaxPods:                    defaultCluster.MaxPods,
+			MaxCount:                   defaultCluster.MaxCount,
+			MessageOfTheDay:            defaultCluster.MessageOfTheDay,
+			MinCount:                   defaultCluster.MinCount,
+			EnableAutoScaling:          defaultCluster.EnableAutoScaling,
+			EnableCustomCATrust:        defaultCluster.EnableCustomCATrust,
+			EnableEncryptionAtHost:     defaultCluster.EnableEncryptionAtHost,
+			EnableFIPS:                 defaultCluster.EnableFIPS,
+			EnableUltraSSD:             defaultCluster.EnableUltraSSD,
+			OrchestratorVersion:        defaultCluster.OrchestratorVersion,
+			ProximityPlacementGroupID:  defaultCluster.ProximityPlacementGroupID,
+			AvailabilityZones:          defaultCluster.AvailabilityZones,
+			EnableNodePublicIP:         defaultCluster.EnableNodePublicIP,
+			NodePublicIPPrefixID:       defaultCluster.NodePublicIPPrefixID,
+			SpotMaxPrice:               defaultCluster.SpotMaxPrice,
+			NodeLabels:                 defaultCluster.NodeLabels,
+			NodeTaints:                 defaultCluster.NodeTaints,
+			PodSubnetID:                defaultCluster.PodSubnetID,
+			CapacityReservationGroupID: defaultCluster.CapacityReservationGroupID,
+			HostGroupID:                defaultCluster.HostGroupID,
+			Tags:                       defaultCluster.Tags,
 		},
 	}
 	if osDisktypeNodePool := defaultCluster.OsDiskType; osDisktypeNodePool != nil {
@@ -1179,8 +1180,11 @@ func ConvertDefaultNodePoolToAgentPool(input *[]managedclusters.ManagedClusterAg
 		agentpool.Properties.ScaleDownMode = pointer.To(agentpools.ScaleDownMode(string(*scaleDownModeNodePool)))
 	}
 	agentpool.Properties.UpgradeSettings = &agentpools.AgentPoolUpgradeSettings{}
-	if upgradeSettingsNodePool := defaultCluster.UpgradeSettings; upgradeSettingsNodePool != nil && upgradeSettingsNodePool.MaxSurge != nil && *upgradeSettingsNodePool.MaxSurge != "" {
-		agentpool.Properties.UpgradeSettings.MaxSurge = upgradeSettingsNodePool.MaxSurge
+	if upgradeSettingsNodePool := defaultCluster.UpgradeSettings; upgradeSettingsNodePool != nil {
+		if upgradeSettingsNodePool.MaxSurge != nil && *upgradeSettingsNodePool.MaxSurge != "" {
+			agentpool.Properties.UpgradeSettings.MaxSurge = upgradeSettingsNodePool.MaxSurge
+		}
+		agentpool.Properties.UpgradeSettings.DrainTimeoutInMinutes = upgradeSettingsNodePool.DrainTimeoutInMinutes
 	}
 	if workloadRuntimeNodePool := defaultCluster.WorkloadRuntime; workloadRuntimeNodePool != nil {
 		agentpool.Properties.WorkloadRuntime = pointer.To(agentpools.WorkloadRuntime(string(*workloadRuntimeNodePool)))
@@ -1201,6 +1205,21 @@ func ConvertDefaultNodePoolToAgentPool(input *[]managedclusters.ManagedClusterAg
 	return agentpool
 }
 
+// validateNodePoolUltraSSDVMSize confirms that the VM Size of a node pool with Ultra SSD enabled isn't one of the A or
+// B-series VM Sizes, which don't support attaching Ultra Disks
+func validateNodePoolUltraSSDVMSize(vmSize string) error {
+	matches := regexp.MustCompile(`(?i)^(?:standard|basic)_([a-z]+)\d`).FindStringSubmatch(vmSize)
+	if len(matches) != 2 {
+		return nil
+	}
+
+	if family := strings.ToUpper(matches[1]); family == "A" || family == "B" {
+		return fmt.Errorf("`ultra_ssd_enabled` isn't supported for the %s-series `vm_size` %q - a VM Size which supports Ultra Disks must be used", family, vmSize)
+	}
+
+	return nil
+}
+
 func ExpandDefaultNodePool(d *pluginsdk.ResourceData) (*[]managedclusters.ManagedClusterAgentPoolProfile, error) {
 	input := d.Get("default_node_pool").([]interface{})
 
@@ -1228,15 +1247,7 @@ func ExpandDefaultNodePool(d *pluginsdk.ResourceData) (*[]managedclusters.Manage
 
 	t := raw["tags"].(map[string]interface{})
 
-	nodePublicIp := raw["enable_node_public_ip"].(bool)
-	if features.FourPointOhBeta() {
-		nodePublicIp = raw["node_public_ip_enabled"].(bool)
-	}
-
-	hostEncryption := raw["enable_host_encryption"].(bool)
-	if features.FourPointOhBeta() {
-		nodePublicIp = raw["host_encryption_enabled"].(bool)
-	}
+	nodePublicIp, hostEncryption := expandDefaultNodePoolNodePublicIPAndHostEncryption(raw, features.FourPointOhBeta())
 
 	profile := managedclusters.ManagedClusterAgentPoolProfile{
 		EnableAutoScaling:      utils.Bool(enableAutoScaling),
@@ -1284,6 +1295,14 @@ func ExpandDefaultNodePool(d *pluginsdk.ResourceData) (*[]managedclusters.Manage
 	}
 
 	if prefixID := raw["node_public_ip_prefix_id"].(string); prefixID != "" {
+		// `RequiredWith` only confirms the field is set, rather than that the node public IPs are enabled
+		if !nodePublicIp {
+			nodePublicIpField := "enable_node_public_ip"
+			if features.FourPointOhBeta() {
+				nodePublicIpField = "node_public_ip_enabled"
+			}
+			return nil, fmt.Errorf("`node_public_ip_prefix_id` can only be specified when the nodes have a public IP - `%s` must be set to `true`", nodePublicIpField)
+		}
 		profile.NodePublicIPPrefixID = utils.String(prefixID)
 	}
 
@@ -1317,6 +1336,11 @@ func ExpandDefaultNodePool(d *pluginsdk.ResourceData) (*[]managedclusters.Manage
 	}
 
 	if ultraSSDEnabled, ok := raw["ultra_ssd_enabled"]; ok {
+		if ultraSSDEnabled.(bool) {
+			if err := validateNodePoolUltraSSDVMSize(raw["vm_size"].(string)); err != nil {
+				return nil, err
+			}
+		}
 		profile.EnableUltraSSD = utils.Bool(ultraSSDEnabled.(bool))
 	}
 
@@ -1582,6 +1606,16 @@ func expandClusterNodePoolSysctlConfig(input []interface{}) (*managedclusters.Sy
 	return result, nil
 }
 
+// expandDefaultNodePoolNodePublicIPAndHostEncryption returns whether node public IPs and host encryption are enabled
+// for the Default Node Pool, which are configured using the renamed fields in 4.0
+func expandDefaultNodePoolNodePublicIPAndHostEncryption(raw map[string]interface{}, fourPointOh bool) (bool, bool) {
+	if fourPointOh {
+		return raw["node_public_ip_enabled"].(bool), raw["host_encryption_enabled"].(bool)
+	}
+
+	return raw["enable_node_public_ip"].(bool), raw["enable_host_encryption"].(bool)
+}
+
 func FlattenDefaultNodePool(input *[]managedclusters.ManagedClusterAgentPoolProfile, d *pluginsdk.ResourceData) (*[]interface{}, error) {
 	if input == nil {
 		return &[]interface{}{}, nil
@@ -1830,17 +1864,24 @@ func FlattenDefaultNodePool(input *[]managedclusters.ManagedClusterAgentPoolProf
 
 func flattenClusterNodePoolUpgradeSettings(input *managedclusters.AgentPoolUpgradeSettings) []interface{} {
 	maxSurge := ""
-	if input != nil && input.MaxSurge != nil {
-		maxSurge = *input.MaxSurge
+	drainTimeoutInMinutes := 0
+	if input != nil {
+		if input.MaxSurge != nil {
+			maxSurge = *input.MaxSurge
+		}
+		if input.DrainTimeoutInMinutes != nil {
+			drainTimeoutInMinutes = int(*input.DrainTimeoutInMinutes)
+		}
 	}
 
-	if maxSurge == "" {
+	if maxSurge == "" && drainTimeoutInMinutes == 0 {
 		return []interface{}{}
 	}
 
 	return []interface{}{
 		map[string]interface{}{
-			"max_surge": maxSurge,
+			"max_surge":                maxSurge,
+			"drain_timeout_in_minutes": drainTimeoutInMinutes,
 		},
 	}
 }
@@ -2206,6 +2247,9 @@ func expandClusterNodePoolUpgradeSettings(input []interface{}) *managedclusters.
 	if maxSurgeRaw := v["max_surge"].(string); maxSurgeRaw != "" {
 		setting.MaxSurge = utils.String(maxSurgeRaw)
 	}
+	if drainTimeoutInMinutesRaw, ok := v["drain_timeout_in_minutes"].(int); ok && drainTimeoutInMinutesRaw != 0 {
+		setting.DrainTimeoutInMinutes = utils.Int64(int64(drainTimeoutInMinutesRaw))
+	}
 	return setting
 }
 
@@ -0,0 +1,328 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package containers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-06-02-preview/managedclusters"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestExpandClusterNodePoolUpgradeSettings(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    []interface{}
+		Expected *managedclusters.AgentPoolUpgradeSettings
+	}{
+		{
+			Name:     "Empty",
+			Input:    []interface{}{},
+			Expected: &managedclusters.AgentPoolUpgradeSettings{},
+		},
+		{
+			Name: "Max Surge",
+			Input: []interface{}{
+				map[string]interface{}{
+					"max_surge":                "10%",
+					"drain_timeout_in_minutes": 0,
+				},
+			},
+			Expected: &managedclusters.AgentPoolUpgradeSettings{
+				MaxSurge: pointer.To("10%"),
+			},
+		},
+		{
+			Name: "Drain Timeout",
+			Input: []interface{}{
+				map[string]interface{}{
+					"max_surge":                "",
+					"drain_timeout_in_minutes": 45,
+				},
+			},
+			Expected: &managedclusters.AgentPoolUpgradeSettings{
+				DrainTimeoutInMinutes: pointer.To(int64(45)),
+			},
+		},
+		{
+			Name: "Max Surge and Drain Timeout",
+			Input: []interface{}{
+				map[string]interface{}{
+					"max_surge":                "2",
+					"drain_timeout_in_minutes": 30,
+				},
+			},
+			Expected: &managedclusters.AgentPoolUpgradeSettings{
+				MaxSurge:              pointer.To("2"),
+				DrainTimeoutInMinutes: pointer.To(int64(30)),
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual := expandClusterNodePoolUpgradeSettings(v.Input)
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
+
+func TestFlattenClusterNodePoolUpgradeSettings(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    *managedclusters.AgentPoolUpgradeSettings
+		Expected []interface{}
+	}{
+		{
+			Name:     "Nil",
+			Input:    nil,
+			Expected: []interface{}{},
+		},
+		{
+			Name:     "Empty",
+			Input:    &managedclusters.AgentPoolUpgradeSettings{},
+			Expected: []interface{}{},
+		},
+		{
+			Name: "Max Surge",
+			Input: &managedclusters.AgentPoolUpgradeSettings{
+				MaxSurge: pointer.To("10%"),
+			},
+			Expected: []interface{}{
+				map[string]interface{}{
+					"max_surge":                "10%",
+					"drain_timeout_in_minutes": 0,
+				},
+			},
+		},
+		{
+			Name: "Drain Timeout",
+			Input: &managedclusters.AgentPoolUpgradeSettings{
+				DrainTimeoutInMinutes: pointer.To(int64(45)),
+			},
+			Expected: []interface{}{
+				map[string]interface{}{
+					"max_surge":                "",
+					"drain_timeout_in_minutes": 45,
+				},
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual := flattenClusterNodePoolUpgradeSettings(v.Input)
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
+
+func TestValidateNodePoolUltraSSDVMSize(t *testing.T) {
+	testData := []struct {
+		VMSize      string
+		ShouldError bool
+	}{
+		{
+			VMSize: "Standard_D2s_v3",
+		},
+		{
+			VMSize: "Standard_DS2_v2",
+		},
+		{
+			VMSize: "Standard_E4ds_v5",
+		},
+		{
+			VMSize:      "Standard_B2ms",
+			ShouldError: true,
+		},
+		{
+			VMSize:      "standard_b4ms",
+			ShouldError: true,
+		},
+		{
+			VMSize:      "Standard_A2_v2",
+			ShouldError: true,
+		},
+		{
+			VMSize:      "Basic_A1",
+			ShouldError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing the VM Size %q", v.VMSize)
+
+		err := validateNodePoolUltraSSDVMSize(v.VMSize)
+		if v.ShouldError && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !v.ShouldError && err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+	}
+}
+
+func TestExpandDefaultNodePoolMessageOfTheDayAndNodePublicIP(t *testing.T) {
+	prefixId := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Network/publicIPPrefixes/prefix1"
+
+	testData := []struct {
+		Name                     string
+		Config                   map[string]interface{}
+		ExpectedMessageOfTheDay  *string
+		ExpectedNodePublicIP     bool
+		ExpectedPublicIPPrefixId *string
+		ShouldError              bool
+	}{
+		{
+			Name:   "neither specified",
+			Config: map[string]interface{}{},
+		},
+		{
+			Name: "message of the day",
+			Config: map[string]interface{}{
+				"message_of_the_day": "daily message",
+			},
+			ExpectedMessageOfTheDay: pointer.To("ZGFpbHkgbWVzc2FnZQ=="),
+		},
+		{
+			Name: "node public ip",
+			Config: map[string]interface{}{
+				"enable_node_public_ip": true,
+			},
+			ExpectedNodePublicIP: true,
+		},
+		{
+			Name: "node public ip with a prefix",
+			Config: map[string]interface{}{
+				"enable_node_public_ip":    true,
+				"node_public_ip_prefix_id": prefixId,
+			},
+			ExpectedNodePublicIP:     true,
+			ExpectedPublicIPPrefixId: pointer.To(prefixId),
+		},
+		{
+			Name: "prefix with node public ip disabled",
+			Config: map[string]interface{}{
+				"enable_node_public_ip":    false,
+				"node_public_ip_prefix_id": prefixId,
+			},
+			ShouldError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		nodePool := map[string]interface{}{
+			"name":    "default",
+			"vm_size": "Standard_D2s_v3",
+		}
+		for key, value := range v.Config {
+			nodePool[key] = value
+		}
+		d := schema.TestResourceDataRaw(t, resourceKubernetesCluster().Schema, map[string]interface{}{
+			"default_node_pool": []interface{}{nodePool},
+		})
+
+		profiles, err := ExpandDefaultNodePool(d)
+		if v.ShouldError {
+			if err == nil {
+				t.Fatalf("expected an error but didn't get one")
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+
+		profile := (*profiles)[0]
+		if !reflect.DeepEqual(profile.MessageOfTheDay, v.ExpectedMessageOfTheDay) {
+			t.Fatalf("expected the message of the day %v but got %v", pointer.From(v.ExpectedMessageOfTheDay), pointer.From(profile.MessageOfTheDay))
+		}
+		if pointer.From(profile.EnableNodePublicIP) != v.ExpectedNodePublicIP {
+			t.Fatalf("expected the node public ip to be %t but got %t", v.ExpectedNodePublicIP, pointer.From(profile.EnableNodePublicIP))
+		}
+		if !reflect.DeepEqual(profile.NodePublicIPPrefixID, v.ExpectedPublicIPPrefixId) {
+			t.Fatalf("expected the node public ip prefix %v but got %v", pointer.From(v.ExpectedPublicIPPrefixId), pointer.From(profile.NodePublicIPPrefixID))
+		}
+
+		// flattening should return the values as configured
+		flattened, err := FlattenDefaultNodePool(profiles, d)
+		if err != nil {
+			t.Fatalf("flattening the Default Node Pool: %+v", err)
+		}
+		actual := (*flattened)[0].(map[string]interface{})
+		for key, value := range v.Config {
+			if actual[key] != value {
+				t.Fatalf("expected the flattened %q to be %v but got %v", key, value, actual[key])
+			}
+		}
+	}
+}
+
+func TestExpandDefaultNodePoolNodePublicIPAndHostEncryption(t *testing.T) {
+	testData := []struct {
+		Name                   string
+		FourPointOh            bool
+		Input                  map[string]interface{}
+		ExpectedNodePublicIP   bool
+		ExpectedHostEncryption bool
+	}{
+		{
+			Name:        "host encryption",
+			FourPointOh: false,
+			Input: map[string]interface{}{
+				"enable_node_public_ip":  false,
+				"enable_host_encryption": true,
+			},
+			ExpectedHostEncryption: true,
+		},
+		{
+			Name:        "node public ip",
+			FourPointOh: false,
+			Input: map[string]interface{}{
+				"enable_node_public_ip":  true,
+				"enable_host_encryption": false,
+			},
+			ExpectedNodePublicIP: true,
+		},
+		{
+			// `host_encryption_enabled` was previously assigned to the node public IP setting
+			Name:        "host encryption in 4.0",
+			FourPointOh: true,
+			Input: map[string]interface{}{
+				"node_public_ip_enabled":  false,
+				"host_encryption_enabled": true,
+			},
+			ExpectedHostEncryption: true,
+		},
+		{
+			Name:        "node public ip in 4.0",
+			FourPointOh: true,
+			Input: map[string]interface{}{
+				"node_public_ip_enabled":  true,
+				"host_encryption_enabled": false,
+			},
+			ExpectedNodePublicIP: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		nodePublicIp, hostEncryption := expandDefaultNodePoolNodePublicIPAndHostEncryption(v.Input, v.FourPointOh)
+		if nodePublicIp != v.ExpectedNodePublicIP {
+			t.Fatalf("expected the node public ip to be %t but got %t", v.ExpectedNodePublicIP, nodePublicIp)
+		}
+		if hostEncryption != v.ExpectedHostEncryption {
+			t.Fatalf("expected host encryption to be %t but got %t", v.ExpectedHostEncryption, hostEncryption)
+		}
+	}
+}
@@ -76,3 +76,22 @@ func KubernetesGitRepositoryUrl() pluginsdk.SchemaValidateFunc {
 		return nil, []error{fmt.Errorf("expected %q to start with `http://`, `https://`, `git@` or `ssh://`", k)}
 	}
 }
+
+// KubernetesMonitorMetricsAllowList validates the allow-list of Kubernetes labels/annotations collected by the
+// kube-state-metrics addon, which is a comma-separated list of `resource=[name,...]` entries, for example
+// `namespaces=[k8s-label-1,k8s-label-n],pods=[app]`
+func KubernetesMonitorMetricsAllowList(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+	}
+
+	item := `[A-Za-z0-9*][-A-Za-z0-9_./*]*`
+	entry := fmt.Sprintf(`[a-z][a-z0-9.]*=\[%[1]s(,%[1]s)*\]`, item)
+	re := regexp.MustCompile(fmt.Sprintf(`^%[1]s(,%[1]s)*$`, entry))
+	if !re.MatchString(v) {
+		errors = append(errors, fmt.Errorf("%q must be a comma-separated list of `resource=[name,...]` entries, such as `namespaces=[k8s-label-1,k8s-label-n],pods=[app]`, got %q", k, v))
+	}
+
+	return warnings, errors
+}
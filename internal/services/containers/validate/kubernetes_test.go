@@ -218,3 +218,61 @@ func TestKubernetesGitRepositoryUrl(t *testing.T) {
 		}
 	}
 }
+
+func TestKubernetesMonitorMetricsAllowList(t *testing.T) {
+	cases := []struct {
+		Input  string
+		Errors int
+	}{
+		{
+			Input:  "",
+			Errors: 1,
+		},
+		{
+			Input:  "namespaces=[k8s-label-1,k8s-label-n]",
+			Errors: 0,
+		},
+		{
+			Input:  "namespaces=[k8s-label-1,k8s-label-n],pods=[app.kubernetes.io/name]",
+			Errors: 0,
+		},
+		{
+			Input:  "pods=[*]",
+			Errors: 0,
+		},
+		{
+			Input:  "namespaces=k8s-label-1",
+			Errors: 1,
+		},
+		{
+			Input:  "namespaces=[]",
+			Errors: 1,
+		},
+		{
+			Input:  "namespaces=[k8s-label-1,]",
+			Errors: 1,
+		},
+		{
+			Input:  "namespaces=[k8s-label-1];pods=[app]",
+			Errors: 1,
+		},
+		{
+			Input:  "=[app]",
+			Errors: 1,
+		},
+		{
+			Input:  "namespaces=[k8s-label-1],",
+			Errors: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Input, func(t *testing.T) {
+			_, errors := KubernetesMonitorMetricsAllowList(tc.Input, "test")
+
+			if len(errors) != tc.Errors {
+				t.Fatalf("Expected KubernetesMonitorMetricsAllowList to return %d error(s) not %d", tc.Errors, len(errors))
+			}
+		})
+	}
+}
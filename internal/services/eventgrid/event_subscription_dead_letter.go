@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package eventgrid
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/blobcontainers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+)
+
+// ensureEventSubscriptionDeadLetterContainer creates the Storage Container used as the dead-letter destination when
+// `create_container_if_not_exists` is enabled and the Storage Container doesn't exist, since otherwise the dead-lettered
+// events fail to be delivered without any error being surfaced
+func ensureEventSubscriptionDeadLetterContainer(ctx context.Context, meta interface{}, input []interface{}) error {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	raw := input[0].(map[string]interface{})
+	if create, ok := raw["create_container_if_not_exists"].(bool); !ok || !create {
+		return nil
+	}
+
+	accountId, err := commonids.ParseStorageAccountIDInsensitively(raw["storage_account_id"].(string))
+	if err != nil {
+		return err
+	}
+
+	client := meta.(*clients.Client).Storage.ResourceManager.BlobContainers
+	id := commonids.NewStorageContainerID(accountId.SubscriptionId, accountId.ResourceGroupName, accountId.StorageAccountName, raw["storage_blob_container_name"].(string))
+
+	existing, err := client.Get(ctx, id)
+	if err == nil {
+		return nil
+	}
+	if !response.WasNotFound(existing.HttpResponse) {
+		return fmt.Errorf("checking for the presence of the dead-letter %s: %+v", id, err)
+	}
+
+	log.Printf("[DEBUG] creating the dead-letter %s..", id)
+	if _, err := client.Create(ctx, id, blobcontainers.BlobContainer{}); err != nil {
+		return fmt.Errorf("creating the dead-letter %s: %+v", id, err)
+	}
+
+	return nil
+}
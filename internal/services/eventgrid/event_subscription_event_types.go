@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package eventgrid
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/eventgrid/2022-06-15/systemtopics"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/eventgrid/2022-06-15/topictypes"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+)
+
+// validateEventSubscriptionIncludedEventTypes returns an error listing any of the `included_event_types` which aren't
+// published by the Topic Type - where no Event Types are available for the Topic Type there's nothing to compare against
+func validateEventSubscriptionIncludedEventTypes(topicType string, includedEventTypes []string, availableEventTypes []string) error {
+	if len(availableEventTypes) == 0 {
+		return nil
+	}
+
+	unknown := make([]string, 0)
+	for _, included := range includedEventTypes {
+		found := false
+		for _, available := range availableEventTypes {
+			if strings.EqualFold(included, available) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			unknown = append(unknown, included)
+		}
+	}
+
+	if len(unknown) > 0 {
+		return fmt.Errorf("the `included_event_types` %q aren't supported by the Topic Type %q - supported Event Types are %q", strings.Join(unknown, ", "), topicType, strings.Join(availableEventTypes, ", "))
+	}
+
+	return nil
+}
+
+// checkSystemTopicEventSubscriptionIncludedEventTypes validates the `included_event_types` against the Event Types
+// published by the Topic Type of the System Topic. Since this is only used to surface a clearer error than the API,
+// failures looking up the System Topic or its Event Types are logged and the check is skipped.
+func checkSystemTopicEventSubscriptionIncludedEventTypes(ctx context.Context, meta interface{}, systemTopicId systemtopics.SystemTopicId, includedEventTypes []string) error {
+	if len(includedEventTypes) == 0 {
+		return nil
+	}
+
+	client := meta.(*clients.Client).EventGrid
+	systemTopic, err := client.SystemTopics.Get(ctx, systemTopicId)
+	if err != nil {
+		log.Printf("[DEBUG] skipping the check of `included_event_types`: retrieving %s: %+v", systemTopicId, err)
+		return nil
+	}
+
+	topicType := ""
+	if model := systemTopic.Model; model != nil && model.Properties != nil {
+		topicType = pointer.From(model.Properties.TopicType)
+	}
+	if topicType == "" {
+		log.Printf("[DEBUG] skipping the check of `included_event_types`: the Topic Type of %s couldn't be determined", systemTopicId)
+		return nil
+	}
+
+	topicTypeId := topictypes.NewTopicTypeID(topicType)
+	resp, err := client.TopicTypes.ListEventTypes(ctx, topicTypeId)
+	if err != nil {
+		log.Printf("[DEBUG] skipping the check of `included_event_types`: listing the Event Types for %s: %+v", topicTypeId, err)
+		return nil
+	}
+
+	availableEventTypes := make([]string, 0)
+	if model := resp.Model; model != nil && model.Value != nil {
+		for _, item := range *model.Value {
+			if item.Name != nil {
+				availableEventTypes = append(availableEventTypes, *item.Name)
+			}
+		}
+	}
+
+	return validateEventSubscriptionIncludedEventTypes(topicType, includedEventTypes, availableEventTypes)
+}
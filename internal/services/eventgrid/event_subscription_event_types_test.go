@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package eventgrid
+
+import (
+	"testing"
+)
+
+func TestValidateEventSubscriptionIncludedEventTypes(t *testing.T) {
+	storageEventTypes := []string{
+		"Microsoft.Storage.BlobCreated",
+		"Microsoft.Storage.BlobDeleted",
+		"Microsoft.Storage.BlobRenamed",
+		"Microsoft.Storage.DirectoryCreated",
+		"Microsoft.Storage.DirectoryDeleted",
+		"Microsoft.Storage.DirectoryRenamed",
+	}
+
+	testData := []struct {
+		Name      string
+		Included  []string
+		Available []string
+		Error     bool
+	}{
+		{
+			Name:      "no included event types",
+			Included:  []string{},
+			Available: storageEventTypes,
+			Error:     false,
+		},
+		{
+			Name:      "valid event types",
+			Included:  []string{"Microsoft.Storage.BlobCreated", "Microsoft.Storage.BlobDeleted"},
+			Available: storageEventTypes,
+			Error:     false,
+		},
+		{
+			Name:      "valid event type with different casing",
+			Included:  []string{"microsoft.storage.blobcreated"},
+			Available: storageEventTypes,
+			Error:     false,
+		},
+		{
+			Name:      "invalid event type",
+			Included:  []string{"Microsoft.Storage.BlobCreated", "Microsoft.Storage.BlobUpdated"},
+			Available: storageEventTypes,
+			Error:     true,
+		},
+		{
+			Name:      "event type from another topic type",
+			Included:  []string{"Microsoft.Resources.ResourceWriteSuccess"},
+			Available: storageEventTypes,
+			Error:     true,
+		},
+		{
+			Name:      "no available event types",
+			Included:  []string{"Microsoft.Storage.BlobUpdated"},
+			Available: []string{},
+			Error:     false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		err := validateEventSubscriptionIncludedEventTypes("Microsoft.Storage.StorageAccounts", v.Included, v.Available)
+		if v.Error && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !v.Error && err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+	}
+}
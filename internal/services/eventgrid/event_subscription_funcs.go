@@ -8,17 +8,19 @@ import (
 	"strings"
 
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/eventgrid/2022-06-15/eventsubscriptions"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/web/2023-01-01/webapps"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
 )
 
-func expandEventSubscriptionDestination(d *pluginsdk.ResourceData) eventsubscriptions.EventSubscriptionDestination {
+func expandEventSubscriptionDestination(d *pluginsdk.ResourceData) (eventsubscriptions.EventSubscriptionDestination, error) {
 	deliveryMappings := expandEventSubscriptionDeliveryAttributeMappings(d.Get("delivery_property").([]interface{}))
 
 	if val, ok := d.GetOk("azure_function_endpoint"); ok && len(val.([]interface{})) == 1 {
-		return expandEventSubscriptionDestinationAzureFunction(d.Get("azure_function_endpoint").([]interface{}), deliveryMappings)
+		return expandEventSubscriptionDestinationAzureFunction(d.Get("azure_function_endpoint").([]interface{}), deliveryMappings), nil
 	}
 
 	eventhubEndpointId, ok := d.GetOk("eventhub_endpoint_id")
@@ -31,7 +33,7 @@ func expandEventSubscriptionDestination(d *pluginsdk.ResourceData) eventsubscrip
 		}
 	}
 	if ok {
-		return expandEventSubscriptionDestinationEventHub(eventhubEndpointId.(string), deliveryMappings)
+		return expandEventSubscriptionDestinationEventHub(eventhubEndpointId.(string), deliveryMappings), nil
 	}
 
 	hybridConnectionEndpointId, ok := d.GetOk("hybrid_connection_endpoint_id")
@@ -44,63 +46,60 @@ func expandEventSubscriptionDestination(d *pluginsdk.ResourceData) eventsubscrip
 		}
 	}
 	if ok {
-		return expandEventSubscriptionDestinationHybridConnection(hybridConnectionEndpointId.(string), deliveryMappings)
+		return expandEventSubscriptionDestinationHybridConnection(hybridConnectionEndpointId.(string), deliveryMappings), nil
 	}
 
 	if val, ok := d.GetOk("service_bus_queue_endpoint_id"); ok {
-		return expandEventSubscriptionDestinationServiceBusQueueEndpoint(val.(string), deliveryMappings)
+		return expandEventSubscriptionDestinationServiceBusQueueEndpoint(val.(string), deliveryMappings), nil
 	}
 
 	if val, ok := d.GetOk("service_bus_topic_endpoint_id"); ok {
-		return expandEventSubscriptionDestinationServiceBusTopicEndpoint(val.(string), deliveryMappings)
+		return expandEventSubscriptionDestinationServiceBusTopicEndpoint(val.(string), deliveryMappings), nil
 	}
 
 	if val, ok := d.GetOk("storage_queue_endpoint"); ok {
-		return expandEventSubscriptionStorageQueueEndpoint(val.([]interface{}))
+		return expandEventSubscriptionStorageQueueEndpoint(val.([]interface{})), nil
 	}
 
 	if val, ok := d.GetOk("webhook_endpoint"); ok {
 		return expandEventGridEventSubscriptionWebhookEndpoint(val.([]interface{}), deliveryMappings)
 	}
 
-	return nil
+	return nil, nil
 }
 
-func expandEventGridEventSubscriptionWebhookEndpoint(input []interface{}, deliveryMappings []eventsubscriptions.DeliveryAttributeMapping) eventsubscriptions.EventSubscriptionDestination {
-	props := eventsubscriptions.WebHookEventSubscriptionDestinationProperties{
-		DeliveryAttributeMappings: &deliveryMappings,
-	}
-	webhookDestination := &eventsubscriptions.WebHookEventSubscriptionDestination{
-		Properties: &props,
-	}
-
-	if len(input) == 0 {
-		return webhookDestination
+func expandEventGridEventSubscriptionWebhookEndpoint(input []interface{}, deliveryMappings []eventsubscriptions.DeliveryAttributeMapping) (eventsubscriptions.EventSubscriptionDestination, error) {
+	if len(input) == 0 || input[0] == nil {
+		return nil, fmt.Errorf("`webhook_endpoint` must be specified")
 	}
 
 	config := input[0].(map[string]interface{})
 
-	if v, ok := config["url"]; ok && v != "" {
-		props.EndpointUrl = pointer.To(v.(string))
-	}
+	options := WebHookEventSubscriptionDestinationOptions{}
 
 	if v, ok := config["max_events_per_batch"]; ok && v != 0 {
-		props.MaxEventsPerBatch = pointer.To(int64(v.(int)))
+		options.MaxEventsPerBatch = pointer.To(int64(v.(int)))
 	}
 
 	if v, ok := config["preferred_batch_size_in_kilobytes"]; ok && v != 0 {
-		props.PreferredBatchSizeInKilobytes = pointer.To(int64(v.(int)))
+		options.PreferredBatchSizeInKilobytes = pointer.To(int64(v.(int)))
 	}
 
 	if v, ok := config["active_directory_tenant_id"]; ok && v != "" {
-		props.AzureActiveDirectoryTenantId = utils.String(v.(string))
+		options.AzureActiveDirectoryTenantId = pointer.To(v.(string))
 	}
 
 	if v, ok := config["active_directory_app_id_or_uri"]; ok && v != "" {
-		props.AzureActiveDirectoryApplicationIdOrUri = utils.String(v.(string))
+		options.AzureActiveDirectoryApplicationIdOrUri = pointer.To(v.(string))
 	}
 
-	return webhookDestination
+	webhookDestination, err := NewWebHookEventSubscriptionDestination(config["url"].(string), options)
+	if err != nil {
+		return nil, err
+	}
+	webhookDestination.Properties.DeliveryAttributeMappings = &deliveryMappings
+
+	return webhookDestination, nil
 }
 
 func expandEventSubscriptionDestinationAzureFunction(input []interface{}, deliveryMappings []eventsubscriptions.DeliveryAttributeMapping) eventsubscriptions.EventSubscriptionDestination {
@@ -130,7 +129,7 @@ func flattenEventSubscriptionDestinationAzureFunction(input eventsubscriptions.E
 	if ok && val.Properties != nil {
 		props := *val.Properties
 		return append(output, map[string]interface{}{
-			"function_id":                       pointer.From(props.ResourceId),
+			"function_id":                       flattenEventSubscriptionDestinationAzureFunctionId(props.ResourceId),
 			"max_events_per_batch":              int(pointer.From(props.MaxEventsPerBatch)),
 			"preferred_batch_size_in_kilobytes": int(pointer.From(props.PreferredBatchSizeInKilobytes)),
 		})
@@ -139,6 +138,20 @@ func flattenEventSubscriptionDestinationAzureFunction(input eventsubscriptions.E
 	return output
 }
 
+// flattenEventSubscriptionDestinationAzureFunctionId returns the canonical form of the Function ID, since the
+// API doesn't necessarily return the ID using the same casing it was sent with
+func flattenEventSubscriptionDestinationAzureFunctionId(input *string) string {
+	functionId := pointer.From(input)
+	if id, err := webapps.ParseFunctionIDInsensitively(functionId); err == nil {
+		return id.ID()
+	}
+	if id, err := webapps.ParseSlotFunctionIDInsensitively(functionId); err == nil {
+		return id.ID()
+	}
+
+	return functionId
+}
+
 func expandEventSubscriptionDestinationEventHub(eventhubEndpointId string, deliveryMappings []eventsubscriptions.DeliveryAttributeMapping) eventsubscriptions.EventSubscriptionDestination {
 	return eventsubscriptions.EventHubEventSubscriptionDestination{
 		Properties: &eventsubscriptions.EventHubEventSubscriptionDestinationProperties{
@@ -359,6 +372,28 @@ func expandEventSubscriptionIdentity(input []interface{}) (*eventsubscriptions.E
 	return &eventgridIdentity, nil
 }
 
+// flattenEventSubscriptionDestinationType returns the type of the Destination, since the Destinations are a discriminated union
+func flattenEventSubscriptionDestinationType(input eventsubscriptions.EventSubscriptionDestination) string {
+	switch input.(type) {
+	case eventsubscriptions.AzureFunctionEventSubscriptionDestination:
+		return string(eventsubscriptions.EndpointTypeAzureFunction)
+	case eventsubscriptions.EventHubEventSubscriptionDestination:
+		return string(eventsubscriptions.EndpointTypeEventHub)
+	case eventsubscriptions.HybridConnectionEventSubscriptionDestination:
+		return string(eventsubscriptions.EndpointTypeHybridConnection)
+	case eventsubscriptions.ServiceBusQueueEventSubscriptionDestination:
+		return string(eventsubscriptions.EndpointTypeServiceBusQueue)
+	case eventsubscriptions.ServiceBusTopicEventSubscriptionDestination:
+		return string(eventsubscriptions.EndpointTypeServiceBusTopic)
+	case eventsubscriptions.StorageQueueEventSubscriptionDestination:
+		return string(eventsubscriptions.EndpointTypeStorageQueue)
+	case eventsubscriptions.WebHookEventSubscriptionDestination:
+		return string(eventsubscriptions.EndpointTypeWebHook)
+	}
+
+	return ""
+}
+
 func flattenEventSubscriptionWebhookEndpoint(input eventsubscriptions.EventSubscriptionDestination, fullUrl *eventsubscriptions.EventSubscriptionFullUrl) []interface{} {
 	output := make([]interface{}, 0)
 	val, ok := input.(eventsubscriptions.WebHookEventSubscriptionDestination)
@@ -408,15 +443,47 @@ func flattenEventSubscriptionWebhookEndpoint(input eventsubscriptions.EventSubsc
 	return output
 }
 
+// flattenEventSubscriptionDeliveryIdentity flattens the identity used for delivery, using the same shape as `dead_letter_identity`
+func flattenEventSubscriptionDeliveryIdentity(input *eventsubscriptions.DeliveryWithResourceIdentity) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	return flattenEventSubscriptionIdentity(input.Identity)
+}
+
+// flattenEventSubscriptionDeadLetterIdentity flattens the identity used for dead-lettering, using the same shape as `delivery_identity`
+func flattenEventSubscriptionDeadLetterIdentity(input *eventsubscriptions.DeadLetterWithResourceIdentity) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	return flattenEventSubscriptionIdentity(input.Identity)
+}
+
 func flattenEventSubscriptionIdentity(input *eventsubscriptions.EventSubscriptionIdentity) []interface{} {
 	if input == nil || input.Type == nil || strings.EqualFold(string(*input.Type), "None") {
 		return []interface{}{}
 	}
 
+	// normalize the casing of the type, since the API doesn't consistently return it
+	identityType := string(*input.Type)
+	for _, v := range eventsubscriptions.PossibleValuesForEventSubscriptionIdentityType() {
+		if strings.EqualFold(identityType, v) {
+			identityType = v
+			break
+		}
+	}
+
+	userAssignedIdentity := ""
+	if identityType == string(eventsubscriptions.EventSubscriptionIdentityTypeUserAssigned) {
+		userAssignedIdentity = pointer.From(input.UserAssignedIdentity)
+	}
+
 	return []interface{}{
 		map[string]interface{}{
-			"type":                   string(*input.Type),
-			"user_assigned_identity": pointer.From(input.UserAssignedIdentity),
+			"type":                   identityType,
+			"user_assigned_identity": userAssignedIdentity,
 		},
 	}
 }
@@ -434,7 +501,7 @@ func flattenEventSubscriptionRetryPolicy(input *eventsubscriptions.RetryPolicy)
 	}
 }
 
-func flattenEventSubscriptionStorageBlobDeadLetterDestination(input eventsubscriptions.DeadLetterDestination) []interface{} {
+func flattenEventSubscriptionStorageBlobDeadLetterDestination(input eventsubscriptions.DeadLetterDestination, existing []interface{}) []interface{} {
 	if input == nil {
 		return []interface{}{}
 	}
@@ -443,10 +510,19 @@ func flattenEventSubscriptionStorageBlobDeadLetterDestination(input eventsubscri
 		return []interface{}{}
 	}
 
+	// this isn't returned by the API so is retrieved from the existing state
+	createContainerIfNotExists := false
+	if len(existing) > 0 && existing[0] != nil {
+		if v, ok := existing[0].(map[string]interface{})["create_container_if_not_exists"].(bool); ok {
+			createContainerIfNotExists = v
+		}
+	}
+
 	return []interface{}{
 		map[string]interface{}{
-			"storage_account_id":          pointer.From(val.Properties.ResourceId),
-			"storage_blob_container_name": pointer.From(val.Properties.BlobContainerName),
+			"storage_account_id":             pointer.From(val.Properties.ResourceId),
+			"storage_blob_container_name":    pointer.From(val.Properties.BlobContainerName),
+			"create_container_if_not_exists": createContainerIfNotExists,
 		},
 	}
 }
@@ -469,9 +545,9 @@ func flattenEventSubscriptionSubjectFilter(input *eventsubscriptions.EventSubscr
 	return output
 }
 
-func flattenEventSubscriptionAdvancedFilter(input *eventsubscriptions.EventSubscriptionFilter) []interface{} {
+func flattenEventSubscriptionAdvancedFilter(input *eventsubscriptions.EventSubscriptionFilter, existing []interface{}) []interface{} {
 	output := make([]interface{}, 0)
-	if input == nil || input.AdvancedFilters == nil {
+	if input == nil || input.AdvancedFilters == nil || len(*input.AdvancedFilters) == 0 {
 		return output
 	}
 
@@ -555,7 +631,7 @@ func flattenEventSubscriptionAdvancedFilter(input *eventsubscriptions.EventSubsc
 		}
 	}
 
-	return []interface{}{
+	output = []interface{}{
 		map[string][]interface{}{
 			"bool_equals":                   boolEquals,
 			"number_greater_than":           numberGreaterThan,
@@ -578,6 +654,51 @@ func flattenEventSubscriptionAdvancedFilter(input *eventsubscriptions.EventSubsc
 			"is_null_or_undefined":          isNullOrUndefined,
 		},
 	}
+
+	// the API doesn't guarantee the order the filters are returned in, so keep the order they were defined in
+	if len(existing) > 0 && existing[0] != nil {
+		filters := output[0].(map[string][]interface{})
+		for operator, existingFilters := range existing[0].(map[string]interface{}) {
+			if v, ok := existingFilters.([]interface{}); ok {
+				filters[operator] = orderEventSubscriptionAdvancedFilterByKey(filters[operator], v)
+			}
+		}
+	}
+
+	return output
+}
+
+// orderEventSubscriptionAdvancedFilterByKey returns the filters for a single operator in the order of the matching keys
+// within the existing filters, with any filters not present in the existing filters appended in the order returned
+func orderEventSubscriptionAdvancedFilterByKey(input []interface{}, existing []interface{}) []interface{} {
+	if len(input) == 0 || len(existing) == 0 {
+		return input
+	}
+
+	output := make([]interface{}, 0, len(input))
+	used := make([]bool, len(input))
+	for _, e := range existing {
+		existingFilter, ok := e.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		for i, item := range input {
+			if !used[i] && item.(map[string]interface{})["key"] == existingFilter["key"] {
+				output = append(output, item)
+				used[i] = true
+				break
+			}
+		}
+	}
+
+	for i, item := range input {
+		if !used[i] {
+			output = append(output, item)
+		}
+	}
+
+	return output
 }
 
 func expandEventSubscriptionRetryPolicy(d *pluginsdk.ResourceData) *eventsubscriptions.RetryPolicy {
@@ -614,18 +735,14 @@ func expandEventSubscriptionFilter(d *pluginsdk.ResourceData) (*eventsubscriptio
 		}
 	}
 
-	if advancedFilter, ok := d.GetOk("advanced_filter"); ok {
-		advancedFilters := make([]eventsubscriptions.AdvancedFilter, 0)
-		for filterKey, filterSchema := range advancedFilter.([]interface{})[0].(map[string]interface{}) {
-			for _, options := range filterSchema.([]interface{}) {
-				if filter, err := expandEventSubscriptionAdvancedFilter(filterKey, options.(map[string]interface{})); err == nil {
-					advancedFilters = append(advancedFilters, filter)
-				} else {
-					return nil, err
-				}
-			}
+	// when all of the advanced filters are removed an explicit empty list needs to be sent, since omitting
+	// the field leaves the existing advanced filters in place
+	if _, ok := d.GetOk("advanced_filter"); ok || d.HasChange("advanced_filter") {
+		advancedFilters, err := expandEventSubscriptionAdvancedFilters(d.Get("advanced_filter").([]interface{}))
+		if err != nil {
+			return nil, err
 		}
-		filter.AdvancedFilters = &advancedFilters
+		filter.AdvancedFilters = advancedFilters
 	}
 
 	if v, ok := d.GetOk("advanced_filtering_on_arrays_enabled"); ok {
@@ -635,8 +752,33 @@ func expandEventSubscriptionFilter(d *pluginsdk.ResourceData) (*eventsubscriptio
 	return filter, nil
 }
 
+func expandEventSubscriptionAdvancedFilters(input []interface{}) (*[]eventsubscriptions.AdvancedFilter, error) {
+	advancedFilters := make([]eventsubscriptions.AdvancedFilter, 0)
+	if len(input) == 0 || input[0] == nil {
+		return &advancedFilters, nil
+	}
+
+	for filterKey, filterSchema := range input[0].(map[string]interface{}) {
+		for _, options := range filterSchema.([]interface{}) {
+			filter, err := expandEventSubscriptionAdvancedFilter(filterKey, options.(map[string]interface{}))
+			if err != nil {
+				return nil, err
+			}
+			advancedFilters = append(advancedFilters, filter)
+		}
+	}
+
+	return &advancedFilters, nil
+}
+
+// eventSubscriptionAdvancedFilterKeyMaxLength is the maximum length of the key used in an Advanced Filter, regardless of the operator
+const eventSubscriptionAdvancedFilterKeyMaxLength = 255
+
 func expandEventSubscriptionAdvancedFilter(operatorType string, config map[string]interface{}) (eventsubscriptions.AdvancedFilter, error) {
 	k := config["key"].(string)
+	if len(k) > eventSubscriptionAdvancedFilterKeyMaxLength {
+		return nil, fmt.Errorf("the `key` of the `advanced_filter` %q can be at most %d characters but got %d characters (%q)", operatorType, eventSubscriptionAdvancedFilterKeyMaxLength, len(k), k)
+	}
 
 	switch operatorType {
 	case "bool_equals":
@@ -739,12 +881,18 @@ func expandEventSubscriptionAdvancedFilter(operatorType string, config map[strin
 		}, nil
 	case "number_in_range":
 		v := utils.ExpandFloatRangeSlice(config["values"].([]interface{}))
+		if err := validateEventSubscriptionAdvancedFilterRanges(operatorType, *v); err != nil {
+			return nil, err
+		}
 		return eventsubscriptions.NumberInRangeAdvancedFilter{
 			Key:    &k,
 			Values: v,
 		}, nil
 	case "number_not_in_range":
 		v := utils.ExpandFloatRangeSlice(config["values"].([]interface{}))
+		if err := validateEventSubscriptionAdvancedFilterRanges(operatorType, *v); err != nil {
+			return nil, err
+		}
 		return eventsubscriptions.NumberNotInRangeAdvancedFilter{
 			Key:    &k,
 			Values: v,
@@ -754,22 +902,75 @@ func expandEventSubscriptionAdvancedFilter(operatorType string, config map[strin
 	}
 }
 
-func expandEventSubscriptionStorageBlobDeadLetterDestination(d *pluginsdk.ResourceData) eventsubscriptions.DeadLetterDestination {
-	if v, ok := d.GetOk("storage_blob_dead_letter_destination"); ok {
-		dest := v.([]interface{})[0].(map[string]interface{})
-		resourceId := dest["storage_account_id"].(string)
-		blobName := dest["storage_blob_container_name"].(string)
-		return eventsubscriptions.StorageBlobDeadLetterDestination{
-			Properties: &eventsubscriptions.StorageBlobDeadLetterDestinationProperties{
-				ResourceId:        &resourceId,
-				BlobContainerName: &blobName,
-			},
+// validateEventSubscriptionAdvancedFilterRanges validates that at least one range is specified, and that each range is
+// specified as a lower and upper bound (in that order) - since a reversed range is accepted by the API but never matches
+func validateEventSubscriptionAdvancedFilterRanges(operatorType string, ranges [][]float64) error {
+	if len(ranges) == 0 {
+		return fmt.Errorf("at least one range must be specified in the `values` of the `advanced_filter` %q", operatorType)
+	}
+
+	for _, r := range ranges {
+		if len(r) != 2 {
+			return fmt.Errorf("each range in the `values` of the `advanced_filter` %q must contain a lower and upper bound but got %v", operatorType, r)
+		}
+		if r[0] > r[1] {
+			return fmt.Errorf("the lower bound of a range in the `values` of the `advanced_filter` %q must not be greater than the upper bound but got %v", operatorType, r)
 		}
 	}
 
 	return nil
 }
 
+// NewStorageBlobDeadLetterDestination returns a Dead Letter Destination which dead-letters events to the Blob Container
+// within the specified Storage Account, validating that the Storage Account ID is a valid Resource ID
+func NewStorageBlobDeadLetterDestination(storageAccountId, containerName string) (eventsubscriptions.DeadLetterDestination, error) {
+	if _, err := commonids.ParseStorageAccountID(storageAccountId); err != nil {
+		return nil, fmt.Errorf("parsing Storage Account ID: %+v", err)
+	}
+	if containerName == "" {
+		return nil, fmt.Errorf("the Blob Container name must not be empty")
+	}
+
+	return eventsubscriptions.StorageBlobDeadLetterDestination{
+		Properties: &eventsubscriptions.StorageBlobDeadLetterDestinationProperties{
+			ResourceId:        pointer.To(storageAccountId),
+			BlobContainerName: pointer.To(containerName),
+		},
+	}, nil
+}
+
+func expandEventSubscriptionStorageBlobDeadLetterDestination(d *pluginsdk.ResourceData) (eventsubscriptions.DeadLetterDestination, error) {
+	if v, ok := d.GetOk("storage_blob_dead_letter_destination"); ok {
+		dest := v.([]interface{})[0].(map[string]interface{})
+		return NewStorageBlobDeadLetterDestination(dest["storage_account_id"].(string), dest["storage_blob_container_name"].(string))
+	}
+
+	return nil, nil
+}
+
+// eventSubscriptionDeadLetterDestinationWarning returns a warning when the dead-letter destination is within the same
+// Storage Account as the delivery destination - since events which can't be delivered (e.g. because the Storage Account
+// is unavailable) are unlikely to be dead-lettered successfully either. Since the Plugin SDK can't surface warnings from
+// a CustomizeDiff this is only logged during apply, rather than shown in the plan.
+func eventSubscriptionDeadLetterDestinationWarning(destination eventsubscriptions.EventSubscriptionDestination, deadLetterDestination eventsubscriptions.DeadLetterDestination) string {
+	storageQueue, ok := destination.(eventsubscriptions.StorageQueueEventSubscriptionDestination)
+	if !ok || storageQueue.Properties == nil {
+		return ""
+	}
+
+	storageBlob, ok := deadLetterDestination.(eventsubscriptions.StorageBlobDeadLetterDestination)
+	if !ok || storageBlob.Properties == nil {
+		return ""
+	}
+
+	storageAccountId := pointer.From(storageQueue.Properties.ResourceId)
+	if storageAccountId == "" || !strings.EqualFold(storageAccountId, pointer.From(storageBlob.Properties.ResourceId)) {
+		return ""
+	}
+
+	return fmt.Sprintf("the `storage_blob_dead_letter_destination` (container %q) uses the same Storage Account %q as the `storage_queue_endpoint` (queue %q) - events which fail to be delivered are unlikely to be dead-lettered successfully, so a separate Storage Account should be used", pointer.From(storageBlob.Properties.BlobContainerName), storageAccountId, pointer.From(storageQueue.Properties.QueueName))
+}
+
 func flattenValue(inputKey *string, inputValue *interface{}) map[string]interface{} {
 	key := ""
 	if inputKey != nil {
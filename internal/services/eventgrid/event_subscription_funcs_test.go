@@ -0,0 +1,934 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package eventgrid
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/eventgrid/2022-06-15/eventsubscriptions"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func TestExpandEventSubscriptionAdvancedFilterEmptyString(t *testing.T) {
+	testData := []struct {
+		OperatorType string
+		Values       []interface{}
+		Expected     []string
+	}{
+		{
+			OperatorType: "string_in",
+			Values:       []interface{}{""},
+			Expected:     []string{""},
+		},
+		{
+			OperatorType: "string_in",
+			Values:       []interface{}{"", "Block"},
+			Expected:     []string{"", "Block"},
+		},
+		{
+			// a null element within the list is sent as an empty string
+			OperatorType: "string_not_in",
+			Values:       []interface{}{nil},
+			Expected:     []string{""},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q with %+v", v.OperatorType, v.Values)
+
+		config := map[string]interface{}{
+			"key":    "data.blobType",
+			"values": v.Values,
+		}
+		expanded, err := expandEventSubscriptionAdvancedFilter(v.OperatorType, config)
+		if err != nil {
+			t.Fatalf("expanding %q: %+v", v.OperatorType, err)
+		}
+
+		filter := &eventsubscriptions.EventSubscriptionFilter{
+			AdvancedFilters: &[]eventsubscriptions.AdvancedFilter{expanded},
+		}
+		flattened := flattenEventSubscriptionAdvancedFilter(filter, nil)
+		if len(flattened) != 1 {
+			t.Fatalf("expected a single `advanced_filter` block but got %d", len(flattened))
+		}
+
+		blocks := flattened[0].(map[string][]interface{})[v.OperatorType]
+		if len(blocks) != 1 {
+			t.Fatalf("expected a single %q block but got %d", v.OperatorType, len(blocks))
+		}
+
+		block := blocks[0].(map[string]interface{})
+		if block["key"] != "data.blobType" {
+			t.Fatalf("expected the key %q but got %q", "data.blobType", block["key"])
+		}
+
+		actual := make([]string, 0)
+		for _, item := range block["values"].([]interface{}) {
+			actual = append(actual, item.(string))
+		}
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("expected the values %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
+
+func TestExpandEventSubscriptionAdvancedFiltersEmpty(t *testing.T) {
+	testData := [][]interface{}{
+		nil,
+		{},
+		{nil},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %+v", v)
+
+		expanded, err := expandEventSubscriptionAdvancedFilters(v)
+		if err != nil {
+			t.Fatalf("expanding %+v: %+v", v, err)
+		}
+
+		// an explicit empty list must be sent to remove any existing advanced filters
+		if expanded == nil {
+			t.Fatalf("expected an empty list of advanced filters but got nil")
+		}
+
+		payload, err := json.Marshal(eventsubscriptions.EventSubscriptionFilter{AdvancedFilters: expanded})
+		if err != nil {
+			t.Fatalf("marshaling: %+v", err)
+		}
+		if !strings.Contains(string(payload), `"advancedFilters":[]`) {
+			t.Fatalf("expected the payload to contain an empty `advancedFilters` list but got %s", string(payload))
+		}
+
+		flattened := flattenEventSubscriptionAdvancedFilter(&eventsubscriptions.EventSubscriptionFilter{AdvancedFilters: expanded}, nil)
+		if len(flattened) != 0 {
+			t.Fatalf("expected no `advanced_filter` blocks but got %d", len(flattened))
+		}
+	}
+}
+
+func TestFlattenEventSubscriptionAdvancedFilterReordered(t *testing.T) {
+	existing := []interface{}{
+		map[string]interface{}{
+			"string_in": []interface{}{
+				map[string]interface{}{"key": "data.blobType", "values": []interface{}{"BlockBlob"}},
+				map[string]interface{}{"key": "data.api", "values": []interface{}{"PutBlob"}},
+				map[string]interface{}{"key": "subject", "values": []interface{}{"example"}},
+			},
+			"is_not_null": []interface{}{
+				map[string]interface{}{"key": "data.url"},
+				map[string]interface{}{"key": "data.contentType"},
+			},
+		},
+	}
+
+	// the filters are returned by the API in a different order to the order they were submitted in
+	filter := &eventsubscriptions.EventSubscriptionFilter{
+		AdvancedFilters: &[]eventsubscriptions.AdvancedFilter{
+			eventsubscriptions.IsNotNullAdvancedFilter{Key: pointer.To("data.contentType")},
+			eventsubscriptions.StringInAdvancedFilter{Key: pointer.To("subject"), Values: &[]string{"example"}},
+			eventsubscriptions.StringInAdvancedFilter{Key: pointer.To("data.api"), Values: &[]string{"PutBlob"}},
+			eventsubscriptions.StringInAdvancedFilter{Key: pointer.To("data.sequencer"), Values: &[]string{"1"}},
+			eventsubscriptions.IsNotNullAdvancedFilter{Key: pointer.To("data.url")},
+			eventsubscriptions.StringInAdvancedFilter{Key: pointer.To("data.blobType"), Values: &[]string{"BlockBlob"}},
+		},
+	}
+
+	testData := []struct {
+		OperatorType string
+		Expected     []string
+	}{
+		{
+			OperatorType: "string_in",
+			// filters which aren't defined in the existing filters are appended in the order returned
+			Expected: []string{"data.blobType", "data.api", "subject", "data.sequencer"},
+		},
+		{
+			OperatorType: "is_not_null",
+			Expected:     []string{"data.url", "data.contentType"},
+		},
+	}
+
+	flattened := flattenEventSubscriptionAdvancedFilter(filter, existing)
+	if len(flattened) != 1 {
+		t.Fatalf("expected a single `advanced_filter` block but got %d", len(flattened))
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.OperatorType)
+
+		actual := make([]string, 0)
+		for _, item := range flattened[0].(map[string][]interface{})[v.OperatorType] {
+			actual = append(actual, item.(map[string]interface{})["key"].(string))
+		}
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("expected the keys %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
+
+func TestFlattenEventSubscriptionStorageBlobDeadLetterDestination(t *testing.T) {
+	input := eventsubscriptions.StorageBlobDeadLetterDestination{
+		Properties: &eventsubscriptions.StorageBlobDeadLetterDestinationProperties{
+			ResourceId:        pointer.To("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1"),
+			BlobContainerName: pointer.To("deadletter"),
+		},
+	}
+
+	testData := []struct {
+		Name     string
+		Existing []interface{}
+		Expected bool
+	}{
+		{
+			Name:     "Imported",
+			Existing: []interface{}{},
+			Expected: false,
+		},
+		{
+			Name: "Container Not Created",
+			Existing: []interface{}{
+				map[string]interface{}{
+					"create_container_if_not_exists": false,
+				},
+			},
+			Expected: false,
+		},
+		{
+			Name: "Container Created",
+			Existing: []interface{}{
+				map[string]interface{}{
+					"create_container_if_not_exists": true,
+				},
+			},
+			Expected: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		flattened := flattenEventSubscriptionStorageBlobDeadLetterDestination(input, v.Existing)
+		if len(flattened) != 1 {
+			t.Fatalf("expected a single `storage_blob_dead_letter_destination` block but got %d", len(flattened))
+		}
+
+		actual := flattened[0].(map[string]interface{})
+		if actual["storage_blob_container_name"] != "deadletter" {
+			t.Fatalf("expected the container name %q but got %q", "deadletter", actual["storage_blob_container_name"])
+		}
+		if actual["create_container_if_not_exists"] != v.Expected {
+			t.Fatalf("expected `create_container_if_not_exists` to be %t but got %t", v.Expected, actual["create_container_if_not_exists"])
+		}
+	}
+}
+
+func TestFlattenEventSubscriptionDestinationAzureFunction(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Expected string
+	}{
+		{
+			Input:    "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resources/providers/Microsoft.Web/sites/example-function-app/functions/example-function",
+			Expected: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resources/providers/Microsoft.Web/sites/example-function-app/functions/example-function",
+		},
+		{
+			// the casing of the segments returned from the API differs from the casing the ID was sent with
+			Input:    "/subscriptions/12345678-1234-9876-4563-123456789012/resourcegroups/example-resources/providers/microsoft.web/sites/example-function-app/functions/example-function",
+			Expected: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resources/providers/Microsoft.Web/sites/example-function-app/functions/example-function",
+		},
+		{
+			Input:    "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resources/providers/Microsoft.Web/sites/example-function-app/slots/staging/functions/example-function",
+			Expected: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resources/providers/Microsoft.Web/sites/example-function-app/slots/staging/functions/example-function",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		expanded := expandEventSubscriptionDestinationAzureFunction([]interface{}{
+			map[string]interface{}{
+				"function_id":                       v.Input,
+				"max_events_per_batch":              0,
+				"preferred_batch_size_in_kilobytes": 0,
+			},
+		}, []eventsubscriptions.DeliveryAttributeMapping{})
+
+		flattened := flattenEventSubscriptionDestinationAzureFunction(expanded)
+		if len(flattened) != 1 {
+			t.Fatalf("expected a single `azure_function_endpoint` block but got %d", len(flattened))
+		}
+
+		actual := flattened[0].(map[string]interface{})["function_id"]
+		if actual != v.Expected {
+			t.Fatalf("expected the Function ID %q but got %q", v.Expected, actual)
+		}
+	}
+}
+
+func TestValidateEventSubscriptionAzureFunctionId(t *testing.T) {
+	testData := []struct {
+		Input string
+		Valid bool
+	}{
+		{
+			Input: "",
+			Valid: false,
+		},
+		{
+			// a Function App rather than a Function
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resources/providers/Microsoft.Web/sites/example-function-app",
+			Valid: false,
+		},
+		{
+			// an endpoint URL rather than a Resource ID
+			Input: "https://example-function-app.azurewebsites.net/runtime/webhooks/EventGrid?functionName=example-function",
+			Valid: false,
+		},
+		{
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resources/providers/Microsoft.Web/sites/example-function-app/functions/example-function",
+			Valid: true,
+		},
+		{
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resources/providers/Microsoft.Web/sites/example-function-app/slots/staging/functions/example-function",
+			Valid: true,
+		},
+	}
+
+	validateFunc := eventSubscriptionSchemaAzureFunctionEndpoint(nil).Elem.(*pluginsdk.Resource).Schema["function_id"].ValidateFunc
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		_, errs := validateFunc(v.Input, "function_id")
+		if valid := len(errs) == 0; valid != v.Valid {
+			t.Fatalf("expected %t but got %t", v.Valid, valid)
+		}
+	}
+}
+
+func TestFlattenEventSubscriptionDeliveryAndDeadLetterIdentity(t *testing.T) {
+	userAssignedIdentityId := "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resources/providers/Microsoft.ManagedIdentity/userAssignedIdentities/example-identity"
+
+	testData := []struct {
+		Name     string
+		Input    *eventsubscriptions.EventSubscriptionIdentity
+		Expected []interface{}
+	}{
+		{
+			Name:     "no identity",
+			Input:    nil,
+			Expected: []interface{}{},
+		},
+		{
+			Name: "type None",
+			Input: &eventsubscriptions.EventSubscriptionIdentity{
+				Type: pointer.To(eventsubscriptions.EventSubscriptionIdentityType("None")),
+			},
+			Expected: []interface{}{},
+		},
+		{
+			Name: "SystemAssigned",
+			Input: &eventsubscriptions.EventSubscriptionIdentity{
+				Type: pointer.To(eventsubscriptions.EventSubscriptionIdentityTypeSystemAssigned),
+			},
+			Expected: []interface{}{
+				map[string]interface{}{
+					"type":                   "SystemAssigned",
+					"user_assigned_identity": "",
+				},
+			},
+		},
+		{
+			Name: "SystemAssigned with different casing",
+			Input: &eventsubscriptions.EventSubscriptionIdentity{
+				Type: pointer.To(eventsubscriptions.EventSubscriptionIdentityType("systemassigned")),
+			},
+			Expected: []interface{}{
+				map[string]interface{}{
+					"type":                   "SystemAssigned",
+					"user_assigned_identity": "",
+				},
+			},
+		},
+		{
+			Name: "UserAssigned",
+			Input: &eventsubscriptions.EventSubscriptionIdentity{
+				Type:                 pointer.To(eventsubscriptions.EventSubscriptionIdentityTypeUserAssigned),
+				UserAssignedIdentity: pointer.To(userAssignedIdentityId),
+			},
+			Expected: []interface{}{
+				map[string]interface{}{
+					"type":                   "UserAssigned",
+					"user_assigned_identity": userAssignedIdentityId,
+				},
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		delivery := flattenEventSubscriptionDeliveryIdentity(&eventsubscriptions.DeliveryWithResourceIdentity{
+			Identity: v.Input,
+		})
+		deadLetter := flattenEventSubscriptionDeadLetterIdentity(&eventsubscriptions.DeadLetterWithResourceIdentity{
+			Identity: v.Input,
+		})
+
+		if !reflect.DeepEqual(delivery, deadLetter) {
+			t.Fatalf("expected the delivery and dead letter identities to match\n\ndelivery: %+v\n\ndead letter: %+v", delivery, deadLetter)
+		}
+		if !reflect.DeepEqual(delivery, v.Expected) {
+			t.Fatalf("expected %+v but got %+v", v.Expected, delivery)
+		}
+	}
+}
+
+// eventSubscriptionAdvancedFilterTestValues contains a valid configuration (excluding the `key`) for each advanced filter operator
+var eventSubscriptionAdvancedFilterTestValues = map[string]map[string]interface{}{
+	"bool_equals":                   {"value": true},
+	"number_greater_than":           {"value": 1.0},
+	"number_greater_than_or_equals": {"value": 1.0},
+	"number_less_than":              {"value": 1.0},
+	"number_less_than_or_equals":    {"value": 1.0},
+	"number_in":                     {"values": []interface{}{1.0}},
+	"number_not_in":                 {"values": []interface{}{1.0}},
+	"number_in_range":               {"values": []interface{}{[]interface{}{1.0, 2.0}}},
+	"number_not_in_range":           {"values": []interface{}{[]interface{}{1.0, 2.0}}},
+	"string_begins_with":            {"values": []interface{}{"a"}},
+	"string_not_begins_with":        {"values": []interface{}{"a"}},
+	"string_ends_with":              {"values": []interface{}{"a"}},
+	"string_not_ends_with":          {"values": []interface{}{"a"}},
+	"string_contains":               {"values": []interface{}{"a"}},
+	"string_not_contains":           {"values": []interface{}{"a"}},
+	"string_in":                     {"values": []interface{}{"a"}},
+	"string_not_in":                 {"values": []interface{}{"a"}},
+	"is_not_null":                   {},
+	"is_null_or_undefined":          {},
+}
+
+func TestExpandEventSubscriptionAdvancedFilterKeyLength(t *testing.T) {
+
+	testData := []struct {
+		Length      int
+		ShouldError bool
+	}{
+		{
+			Length: 1,
+		},
+		{
+			Length: eventSubscriptionAdvancedFilterKeyMaxLength,
+		},
+		{
+			Length:      eventSubscriptionAdvancedFilterKeyMaxLength + 1,
+			ShouldError: true,
+		},
+	}
+
+	for operatorType, values := range eventSubscriptionAdvancedFilterTestValues {
+		for _, v := range testData {
+			t.Logf("[DEBUG] Testing %q with a key of %d characters", operatorType, v.Length)
+
+			config := map[string]interface{}{
+				"key": strings.Repeat("a", v.Length),
+			}
+			for key, value := range values {
+				config[key] = value
+			}
+
+			_, err := expandEventSubscriptionAdvancedFilter(operatorType, config)
+			if v.ShouldError && err == nil {
+				t.Fatalf("expected an error but didn't get one")
+			}
+			if !v.ShouldError && err != nil {
+				t.Fatalf("expected no error but got: %+v", err)
+			}
+		}
+	}
+}
+
+func TestExpandEventSubscriptionAdvancedFilterEventTime(t *testing.T) {
+	testData := []struct {
+		Name         string
+		OperatorType string
+		Config       map[string]interface{}
+	}{
+		{
+			Name:         "event time prefix",
+			OperatorType: "string_begins_with",
+			Config:       map[string]interface{}{"key": "eventTime", "values": []interface{}{"2023-01-"}},
+		},
+		{
+			Name:         "cloud event time in a list of dates",
+			OperatorType: "string_in",
+			Config:       map[string]interface{}{"key": "time", "values": []interface{}{"2023-01-02T03:04:05Z"}},
+		},
+		{
+			// a custom input schema can map a numeric value to the top-level `time` key
+			Name:         "time compared as a number",
+			OperatorType: "number_greater_than",
+			Config:       map[string]interface{}{"key": "time", "value": 1.0},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		filter, err := expandEventSubscriptionAdvancedFilter(v.OperatorType, v.Config)
+		if err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+
+		if beginsWith, ok := filter.(eventsubscriptions.StringBeginsWithAdvancedFilter); ok {
+			if !reflect.DeepEqual(*beginsWith.Values, []string{"2023-01-"}) {
+				t.Fatalf("expected the event time prefix to be passed through as-is but got %+v", *beginsWith.Values)
+			}
+		}
+	}
+}
+
+func TestEventSubscriptionAdvancedFilterJSONRoundTrip(t *testing.T) {
+	for operatorType, values := range eventSubscriptionAdvancedFilterTestValues {
+		t.Logf("[DEBUG] Testing %q", operatorType)
+
+		config := map[string]interface{}{
+			"key": "data.key",
+		}
+		for key, value := range values {
+			config[key] = value
+		}
+
+		expected, err := expandEventSubscriptionAdvancedFilter(operatorType, config)
+		if err != nil {
+			t.Fatalf("expanding: %+v", err)
+		}
+
+		payload, err := json.Marshal(eventsubscriptions.EventSubscriptionFilter{
+			AdvancedFilters: &[]eventsubscriptions.AdvancedFilter{expected},
+		})
+		if err != nil {
+			t.Fatalf("marshaling: %+v", err)
+		}
+
+		var actual eventsubscriptions.EventSubscriptionFilter
+		if err := json.Unmarshal(payload, &actual); err != nil {
+			t.Fatalf("unmarshaling: %+v", err)
+		}
+		if actual.AdvancedFilters == nil || len(*actual.AdvancedFilters) != 1 {
+			t.Fatalf("expected 1 advanced filter but got: %+v", actual.AdvancedFilters)
+		}
+
+		filter := (*actual.AdvancedFilters)[0]
+		if reflect.TypeOf(filter) != reflect.TypeOf(expected) {
+			t.Fatalf("expected the advanced filter to be unmarshaled as %T but got %T", expected, filter)
+		}
+		if !reflect.DeepEqual(filter, expected) {
+			t.Fatalf("expected %+v but got %+v", expected, filter)
+		}
+
+		flattened := flattenEventSubscriptionAdvancedFilter(&actual, nil)
+		if len(flattened) != 1 {
+			t.Fatalf("expected 1 flattened advanced filter block but got %d", len(flattened))
+		}
+		block := flattened[0].(map[string][]interface{})
+		if items := block[operatorType]; len(items) != 1 {
+			t.Fatalf("expected 1 %q advanced filter to be flattened but got: %+v", operatorType, block[operatorType])
+		}
+	}
+}
+
+func TestExpandEventSubscriptionAdvancedFilterBoolAndRanges(t *testing.T) {
+	// single values are flattened as a pointer to the value returned by the API
+	boolValue := interface{}(pointer.To(false))
+
+	testData := []struct {
+		Name         string
+		OperatorType string
+		Config       map[string]interface{}
+		Expected     eventsubscriptions.AdvancedFilter
+		Flattened    map[string]interface{}
+		ShouldError  bool
+	}{
+		{
+			Name:         "bool equals",
+			OperatorType: "bool_equals",
+			Config:       map[string]interface{}{"key": "data.enabled", "value": false},
+			Expected: eventsubscriptions.BoolEqualsAdvancedFilter{
+				Key:   pointer.To("data.enabled"),
+				Value: pointer.To(false),
+			},
+			Flattened: map[string]interface{}{"key": "data.enabled", "value": &boolValue},
+		},
+		{
+			Name:         "number not in multiple ranges",
+			OperatorType: "number_not_in_range",
+			Config:       map[string]interface{}{"key": "data.count", "values": []interface{}{[]interface{}{1.0, 5.0}, []interface{}{10.0, 10.0}}},
+			Expected: eventsubscriptions.NumberNotInRangeAdvancedFilter{
+				Key:    pointer.To("data.count"),
+				Values: &[][]float64{{1.0, 5.0}, {10.0, 10.0}},
+			},
+			Flattened: map[string]interface{}{"key": "data.count", "values": []interface{}{[]interface{}{1.0, 5.0}, []interface{}{10.0, 10.0}}},
+		},
+		{
+			Name:         "number not in a reversed range",
+			OperatorType: "number_not_in_range",
+			Config:       map[string]interface{}{"key": "data.count", "values": []interface{}{[]interface{}{5.0, 1.0}}},
+			ShouldError:  true,
+		},
+		{
+			Name:         "number in a reversed range",
+			OperatorType: "number_in_range",
+			Config:       map[string]interface{}{"key": "data.count", "values": []interface{}{[]interface{}{1.0, 2.0}, []interface{}{5.0, 1.0}}},
+			ShouldError:  true,
+		},
+		{
+			Name:         "number not in no ranges",
+			OperatorType: "number_not_in_range",
+			Config:       map[string]interface{}{"key": "data.count", "values": []interface{}{}},
+			ShouldError:  true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual, err := expandEventSubscriptionAdvancedFilter(v.OperatorType, v.Config)
+		if v.ShouldError {
+			if err == nil {
+				t.Fatalf("expected an error but didn't get one")
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("expected %+v but got %+v", v.Expected, actual)
+		}
+
+		flattened := flattenEventSubscriptionAdvancedFilter(&eventsubscriptions.EventSubscriptionFilter{
+			AdvancedFilters: &[]eventsubscriptions.AdvancedFilter{actual},
+		}, nil)
+		block := flattened[0].(map[string][]interface{})[v.OperatorType]
+		if len(block) != 1 {
+			t.Fatalf("expected 1 %q advanced filter to be flattened but got: %+v", v.OperatorType, block)
+		}
+		if !reflect.DeepEqual(block[0], v.Flattened) {
+			t.Fatalf("expected %+v to be flattened but got %+v", v.Flattened, block[0])
+		}
+	}
+}
+
+func TestFlattenEventSubscriptionDestinationType(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    eventsubscriptions.EventSubscriptionDestination
+		Expected string
+	}{
+		{
+			Name:     "no destination",
+			Input:    nil,
+			Expected: "",
+		},
+		{
+			Name: "Event Hub",
+			Input: eventsubscriptions.EventHubEventSubscriptionDestination{
+				Properties: &eventsubscriptions.EventHubEventSubscriptionDestinationProperties{
+					ResourceId: pointer.To("/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resources/providers/Microsoft.EventHub/namespaces/example-namespace/eventhubs/example-eventhub"),
+				},
+			},
+			Expected: "EventHub",
+		},
+		{
+			Name: "Storage Queue",
+			Input: eventsubscriptions.StorageQueueEventSubscriptionDestination{
+				Properties: &eventsubscriptions.StorageQueueEventSubscriptionDestinationProperties{
+					QueueName: pointer.To("example-queue"),
+				},
+			},
+			Expected: "StorageQueue",
+		},
+		{
+			Name: "Webhook",
+			Input: eventsubscriptions.WebHookEventSubscriptionDestination{
+				Properties: &eventsubscriptions.WebHookEventSubscriptionDestinationProperties{
+					EndpointBaseUrl: pointer.To("https://example.com/api/events"),
+				},
+			},
+			Expected: "WebHook",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual := flattenEventSubscriptionDestinationType(v.Input)
+		if actual != v.Expected {
+			t.Fatalf("expected %q but got %q", v.Expected, actual)
+		}
+	}
+}
+
+func TestValidateEventSubscriptionScope(t *testing.T) {
+	testData := []struct {
+		Input string
+		Valid bool
+	}{
+		{
+			Input: "",
+			Valid: false,
+		},
+		{
+			Input: "/providers/Microsoft.Management/managementGroups/example-group",
+			Valid: true,
+		},
+		{
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012",
+			Valid: true,
+		},
+		{
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resources",
+			Valid: true,
+		},
+		{
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resources/providers/Microsoft.Storage/storageAccounts/example",
+			Valid: true,
+		},
+		{
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resources/providers/Microsoft.EventGrid/domains/example/topics/example",
+			Valid: true,
+		},
+		{
+			// resources outside of a Resource Group
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/providers/Microsoft.Example/things/example",
+			Valid: true,
+		},
+		{
+			// resources outside of a Subscription
+			Input: "/providers/Microsoft.Example/things/example",
+			Valid: true,
+		},
+		{
+			Input: "/providers/Microsoft.Management/managementGroups",
+			Valid: false,
+		},
+		{
+			Input: "example-resources",
+			Valid: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		_, errors := validateEventSubscriptionScope(v.Input, "scope")
+		if valid := len(errors) == 0; valid != v.Valid {
+			t.Fatalf("expected %t but got %t (%+v)", v.Valid, valid, errors)
+		}
+	}
+}
+
+func TestFlattenEventSubscriptionScope(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    string
+		Existing string
+		Expected string
+	}{
+		{
+			Name:     "management group when importing",
+			Input:    "/providers/microsoft.management/managementgroups/example-group",
+			Expected: "/providers/Microsoft.Management/managementGroups/example-group",
+		},
+		{
+			Name:     "subscription when importing",
+			Input:    "/SUBSCRIPTIONS/12345678-1234-9876-4563-123456789012",
+			Expected: "/subscriptions/12345678-1234-9876-4563-123456789012",
+		},
+		{
+			Name:     "resource group when importing",
+			Input:    "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resources",
+			Expected: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resources",
+		},
+		{
+			// the existing value is retained so that this doesn't cause a diff, since the scope is ForceNew
+			Name:     "management group differing in casing",
+			Input:    "/providers/microsoft.management/managementgroups/example-group",
+			Existing: "/providers/Microsoft.Management/managementgroups/Example-Group",
+			Expected: "/providers/Microsoft.Management/managementgroups/Example-Group",
+		},
+		{
+			Name:     "subscription differing in casing",
+			Input:    "/SUBSCRIPTIONS/12345678-1234-9876-4563-123456789012",
+			Existing: "/Subscriptions/12345678-1234-9876-4563-123456789012",
+			Expected: "/Subscriptions/12345678-1234-9876-4563-123456789012",
+		},
+		{
+			Name:     "different subscription",
+			Input:    "/SUBSCRIPTIONS/12345678-1234-9876-4563-123456789012",
+			Existing: "/subscriptions/00000000-0000-0000-0000-000000000000",
+			Expected: "/subscriptions/12345678-1234-9876-4563-123456789012",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual := flattenEventSubscriptionScope(v.Input, v.Existing)
+		if actual != v.Expected {
+			t.Fatalf("expected %q but got %q", v.Expected, actual)
+		}
+	}
+}
+
+func TestEventSubscriptionDeadLetterDestinationWarning(t *testing.T) {
+	storageAccountId := "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resources/providers/Microsoft.Storage/storageAccounts/example"
+	otherStorageAccountId := "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resources/providers/Microsoft.Storage/storageAccounts/other"
+
+	storageQueue := eventsubscriptions.StorageQueueEventSubscriptionDestination{
+		Properties: &eventsubscriptions.StorageQueueEventSubscriptionDestinationProperties{
+			ResourceId: pointer.To(storageAccountId),
+			QueueName:  pointer.To("events"),
+		},
+	}
+
+	testData := []struct {
+		Name                  string
+		Destination           eventsubscriptions.EventSubscriptionDestination
+		DeadLetterDestination eventsubscriptions.DeadLetterDestination
+		Warning               bool
+	}{
+		{
+			Name:                  "no dead-letter destination",
+			Destination:           storageQueue,
+			DeadLetterDestination: nil,
+			Warning:               false,
+		},
+		{
+			Name:        "different storage account",
+			Destination: storageQueue,
+			DeadLetterDestination: eventsubscriptions.StorageBlobDeadLetterDestination{
+				Properties: &eventsubscriptions.StorageBlobDeadLetterDestinationProperties{
+					ResourceId:        pointer.To(otherStorageAccountId),
+					BlobContainerName: pointer.To("deadletter"),
+				},
+			},
+			Warning: false,
+		},
+		{
+			Name:        "same storage account",
+			Destination: storageQueue,
+			DeadLetterDestination: eventsubscriptions.StorageBlobDeadLetterDestination{
+				Properties: &eventsubscriptions.StorageBlobDeadLetterDestinationProperties{
+					ResourceId:        pointer.To(storageAccountId),
+					BlobContainerName: pointer.To("deadletter"),
+				},
+			},
+			Warning: true,
+		},
+		{
+			Name:        "same storage account with different casing",
+			Destination: storageQueue,
+			DeadLetterDestination: eventsubscriptions.StorageBlobDeadLetterDestination{
+				Properties: &eventsubscriptions.StorageBlobDeadLetterDestinationProperties{
+					ResourceId:        pointer.To(strings.ToLower(storageAccountId)),
+					BlobContainerName: pointer.To("deadletter"),
+				},
+			},
+			Warning: true,
+		},
+		{
+			Name: "non-storage destination",
+			Destination: eventsubscriptions.EventHubEventSubscriptionDestination{
+				Properties: &eventsubscriptions.EventHubEventSubscriptionDestinationProperties{
+					ResourceId: pointer.To("/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resources/providers/Microsoft.EventHub/namespaces/example/eventhubs/example"),
+				},
+			},
+			DeadLetterDestination: eventsubscriptions.StorageBlobDeadLetterDestination{
+				Properties: &eventsubscriptions.StorageBlobDeadLetterDestinationProperties{
+					ResourceId:        pointer.To(storageAccountId),
+					BlobContainerName: pointer.To("deadletter"),
+				},
+			},
+			Warning: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		warning := eventSubscriptionDeadLetterDestinationWarning(v.Destination, v.DeadLetterDestination)
+		if v.Warning && warning == "" {
+			t.Fatalf("expected a warning but didn't get one")
+		}
+		if !v.Warning && warning != "" {
+			t.Fatalf("expected no warning but got %q", warning)
+		}
+	}
+}
+
+func TestNewStorageBlobDeadLetterDestination(t *testing.T) {
+	testData := []struct {
+		Name             string
+		StorageAccountId string
+		ContainerName    string
+		Error            bool
+	}{
+		{
+			Name:             "valid",
+			StorageAccountId: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resources/providers/Microsoft.Storage/storageAccounts/example",
+			ContainerName:    "deadletter",
+			Error:            false,
+		},
+		{
+			Name:             "storage container id",
+			StorageAccountId: "https://example.blob.core.windows.net/deadletter",
+			ContainerName:    "deadletter",
+			Error:            true,
+		},
+		{
+			Name:             "storage queue id",
+			StorageAccountId: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resources/providers/Microsoft.Storage/storageAccounts/example/queueServices/default/queues/events",
+			ContainerName:    "deadletter",
+			Error:            true,
+		},
+		{
+			Name:             "empty container name",
+			StorageAccountId: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resources/providers/Microsoft.Storage/storageAccounts/example",
+			ContainerName:    "",
+			Error:            true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual, err := NewStorageBlobDeadLetterDestination(v.StorageAccountId, v.ContainerName)
+		if v.Error {
+			if err == nil {
+				t.Fatalf("expected an error but didn't get one")
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+
+		destination, ok := actual.(eventsubscriptions.StorageBlobDeadLetterDestination)
+		if !ok || destination.Properties == nil {
+			t.Fatalf("expected a StorageBlobDeadLetterDestination but got %+v", actual)
+		}
+		if pointer.From(destination.Properties.ResourceId) != v.StorageAccountId {
+			t.Fatalf("expected the Resource ID to be %q but got %q", v.StorageAccountId, pointer.From(destination.Properties.ResourceId))
+		}
+		if pointer.From(destination.Properties.BlobContainerName) != v.ContainerName {
+			t.Fatalf("expected the Blob Container Name to be %q but got %q", v.ContainerName, pointer.From(destination.Properties.BlobContainerName))
+		}
+	}
+}
@@ -12,8 +12,8 @@ import (
 	"github.com/hashicorp/go-azure-sdk/resource-manager/relay/2021-11-01/hybridconnections"
 	serviceBusQueues "github.com/hashicorp/go-azure-sdk/resource-manager/servicebus/2021-06-01-preview/queues"
 	serviceBusTopics "github.com/hashicorp/go-azure-sdk/resource-manager/servicebus/2021-06-01-preview/topics"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/web/2023-01-01/webapps"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/suppress"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
@@ -122,9 +122,12 @@ func eventSubscriptionSchemaAzureFunctionEndpoint(conflictsWith []string) *plugi
 		Elem: &pluginsdk.Resource{
 			Schema: map[string]*pluginsdk.Schema{
 				"function_id": {
-					Type:         pluginsdk.TypeString,
-					Required:     true,
-					ValidateFunc: azure.ValidateResourceID, // TODO: validation for a Function App ID
+					Type:     pluginsdk.TypeString,
+					Required: true,
+					ValidateFunc: validation.Any(
+						webapps.ValidateFunctionID,
+						webapps.ValidateSlotFunctionID,
+					),
 				},
 				"max_events_per_batch": {
 					Type:     pluginsdk.TypeInt,
@@ -224,12 +227,12 @@ func eventSubscriptionSchemaWebHookEndpoint(conflictsWith []string) *pluginsdk.S
 				"max_events_per_batch": {
 					Type:         pluginsdk.TypeInt,
 					Optional:     true,
-					ValidateFunc: validation.IntBetween(1, 5000),
+					ValidateFunc: validation.IntBetween(1, webHookEventSubscriptionMaxEventsPerBatchMaximum),
 				},
 				"preferred_batch_size_in_kilobytes": {
 					Type:         pluginsdk.TypeInt,
 					Optional:     true,
-					ValidateFunc: validation.IntBetween(1, 1024),
+					ValidateFunc: validation.IntBetween(1, webHookEventSubscriptionPreferredBatchSizeInKilobytesMaximum),
 				},
 				"active_directory_tenant_id": {
 					Type:     pluginsdk.TypeString,
@@ -244,6 +247,13 @@ func eventSubscriptionSchemaWebHookEndpoint(conflictsWith []string) *pluginsdk.S
 	}
 }
 
+func eventSubscriptionSchemaDestinationType() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeString,
+		Computed: true,
+	}
+}
+
 func eventSubscriptionSchemaIncludedEventTypes() *pluginsdk.Schema {
 	return &pluginsdk.Schema{
 		Type:     pluginsdk.TypeList,
@@ -718,6 +728,11 @@ func eventSubscriptionSchemaStorageBlobDeadletterDestination() *pluginsdk.Schema
 					Required:     true,
 					ValidateFunc: validation.StringIsNotEmpty,
 				},
+				"create_container_if_not_exists": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
 			},
 		},
 	}
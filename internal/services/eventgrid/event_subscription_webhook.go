@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package eventgrid
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/eventgrid/2022-06-15/eventsubscriptions"
+)
+
+const (
+	webHookEventSubscriptionMaxEventsPerBatchMaximum             = 5000
+	webHookEventSubscriptionPreferredBatchSizeInKilobytesMaximum = 1024
+)
+
+// WebHookEventSubscriptionDestinationOptions are the optional settings for a WebHook Event Subscription Destination,
+// where the Azure Active Directory Tenant and Application are used to authenticate the delivery of events
+type WebHookEventSubscriptionDestinationOptions struct {
+	AzureActiveDirectoryTenantId           *string
+	AzureActiveDirectoryApplicationIdOrUri *string
+
+	MaxEventsPerBatch             *int64
+	PreferredBatchSizeInKilobytes *int64
+}
+
+// NewWebHookEventSubscriptionDestination returns a WebHook Event Subscription Destination for the specified endpoint,
+// validating that the Azure Active Directory Tenant and Application are either both specified or both omitted
+func NewWebHookEventSubscriptionDestination(endpointUrl string, options WebHookEventSubscriptionDestinationOptions) (*eventsubscriptions.WebHookEventSubscriptionDestination, error) {
+	if endpointUrl == "" {
+		return nil, fmt.Errorf("the endpoint URL for a WebHook Event Subscription Destination must be specified")
+	}
+
+	tenantId := pointer.From(options.AzureActiveDirectoryTenantId)
+	applicationIdOrUri := pointer.From(options.AzureActiveDirectoryApplicationIdOrUri)
+	if (tenantId == "") != (applicationIdOrUri == "") {
+		return nil, fmt.Errorf("the Azure Active Directory Tenant ID and Application ID (or URI) for a WebHook Event Subscription Destination must either both be specified or both be omitted")
+	}
+
+	if err := ValidateWebHookEventSubscriptionDestinationBatching(options.MaxEventsPerBatch, options.PreferredBatchSizeInKilobytes); err != nil {
+		return nil, err
+	}
+
+	props := eventsubscriptions.WebHookEventSubscriptionDestinationProperties{
+		EndpointUrl:                   pointer.To(endpointUrl),
+		MaxEventsPerBatch:             options.MaxEventsPerBatch,
+		PreferredBatchSizeInKilobytes: options.PreferredBatchSizeInKilobytes,
+	}
+	if tenantId != "" {
+		props.AzureActiveDirectoryTenantId = pointer.To(tenantId)
+		props.AzureActiveDirectoryApplicationIdOrUri = pointer.To(applicationIdOrUri)
+	}
+
+	return &eventsubscriptions.WebHookEventSubscriptionDestination{
+		Properties: &props,
+	}, nil
+}
+
+// ValidateWebHookEventSubscriptionDestinationBatching validates that the maximum number of events per batch and the
+// preferred batch size (when specified) are within the ranges accepted by the API
+func ValidateWebHookEventSubscriptionDestinationBatching(maxEventsPerBatch, preferredBatchSizeInKilobytes *int64) error {
+	if v := maxEventsPerBatch; v != nil && (*v < 1 || *v > webHookEventSubscriptionMaxEventsPerBatchMaximum) {
+		return fmt.Errorf("the maximum number of events per batch must be between 1 and %d but got %d", webHookEventSubscriptionMaxEventsPerBatchMaximum, *v)
+	}
+
+	if v := preferredBatchSizeInKilobytes; v != nil && (*v < 1 || *v > webHookEventSubscriptionPreferredBatchSizeInKilobytesMaximum) {
+		return fmt.Errorf("the preferred batch size must be between 1 and %d kilobytes but got %d", webHookEventSubscriptionPreferredBatchSizeInKilobytesMaximum, *v)
+	}
+
+	return nil
+}
@@ -0,0 +1,243 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package eventgrid
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/eventgrid/2022-06-15/eventsubscriptions"
+)
+
+func TestNewWebHookEventSubscriptionDestination(t *testing.T) {
+	testData := []struct {
+		Name        string
+		EndpointUrl string
+		Options     WebHookEventSubscriptionDestinationOptions
+		Expected    map[string]interface{}
+		ExpectError bool
+	}{
+		{
+			Name:        "No Endpoint URL",
+			EndpointUrl: "",
+			ExpectError: true,
+		},
+		{
+			Name:        "Without Authentication",
+			EndpointUrl: "https://example.com/webhook",
+			Expected: map[string]interface{}{
+				"endpointType": "WebHook",
+				"properties": map[string]interface{}{
+					"endpointUrl": "https://example.com/webhook",
+				},
+			},
+		},
+		{
+			Name:        "With Authentication",
+			EndpointUrl: "https://example.com/webhook",
+			Options: WebHookEventSubscriptionDestinationOptions{
+				AzureActiveDirectoryTenantId:           pointer.To("00000000-0000-0000-0000-000000000000"),
+				AzureActiveDirectoryApplicationIdOrUri: pointer.To("api://example"),
+			},
+			Expected: map[string]interface{}{
+				"endpointType": "WebHook",
+				"properties": map[string]interface{}{
+					"endpointUrl":                            "https://example.com/webhook",
+					"azureActiveDirectoryTenantId":           "00000000-0000-0000-0000-000000000000",
+					"azureActiveDirectoryApplicationIdOrUri": "api://example",
+				},
+			},
+		},
+		{
+			Name:        "Empty Authentication",
+			EndpointUrl: "https://example.com/webhook",
+			Options: WebHookEventSubscriptionDestinationOptions{
+				AzureActiveDirectoryTenantId:           pointer.To(""),
+				AzureActiveDirectoryApplicationIdOrUri: pointer.To(""),
+			},
+			Expected: map[string]interface{}{
+				"endpointType": "WebHook",
+				"properties": map[string]interface{}{
+					"endpointUrl": "https://example.com/webhook",
+				},
+			},
+		},
+		{
+			Name:        "With Batching",
+			EndpointUrl: "https://example.com/webhook",
+			Options: WebHookEventSubscriptionDestinationOptions{
+				MaxEventsPerBatch:             pointer.To(int64(100)),
+				PreferredBatchSizeInKilobytes: pointer.To(int64(64)),
+			},
+			Expected: map[string]interface{}{
+				"endpointType": "WebHook",
+				"properties": map[string]interface{}{
+					"endpointUrl":                   "https://example.com/webhook",
+					"maxEventsPerBatch":             float64(100),
+					"preferredBatchSizeInKilobytes": float64(64),
+				},
+			},
+		},
+		{
+			Name:        "Invalid Batching",
+			EndpointUrl: "https://example.com/webhook",
+			Options: WebHookEventSubscriptionDestinationOptions{
+				MaxEventsPerBatch: pointer.To(int64(5001)),
+			},
+			ExpectError: true,
+		},
+		{
+			Name:        "Tenant Without Application",
+			EndpointUrl: "https://example.com/webhook",
+			Options: WebHookEventSubscriptionDestinationOptions{
+				AzureActiveDirectoryTenantId: pointer.To("00000000-0000-0000-0000-000000000000"),
+			},
+			ExpectError: true,
+		},
+		{
+			Name:        "Application Without Tenant",
+			EndpointUrl: "https://example.com/webhook",
+			Options: WebHookEventSubscriptionDestinationOptions{
+				AzureActiveDirectoryApplicationIdOrUri: pointer.To("api://example"),
+			},
+			ExpectError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.Name)
+
+		destination, err := NewWebHookEventSubscriptionDestination(v.EndpointUrl, v.Options)
+		if err != nil {
+			if v.ExpectError {
+				continue
+			}
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+		if v.ExpectError {
+			t.Fatalf("expected an error but didn't get one")
+		}
+
+		encoded, err := json.Marshal(destination)
+		if err != nil {
+			t.Fatalf("marshaling: %+v", err)
+		}
+
+		var actual map[string]interface{}
+		if err := json.Unmarshal(encoded, &actual); err != nil {
+			t.Fatalf("unmarshaling: %+v", err)
+		}
+
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
+
+func TestValidateWebHookEventSubscriptionDestinationBatching(t *testing.T) {
+	testData := []struct {
+		MaxEventsPerBatch             *int64
+		PreferredBatchSizeInKilobytes *int64
+		ExpectError                   bool
+	}{
+		{},
+		{
+			MaxEventsPerBatch:             pointer.To(int64(1)),
+			PreferredBatchSizeInKilobytes: pointer.To(int64(1)),
+		},
+		{
+			MaxEventsPerBatch:             pointer.To(int64(5000)),
+			PreferredBatchSizeInKilobytes: pointer.To(int64(1024)),
+		},
+		{
+			MaxEventsPerBatch: pointer.To(int64(0)),
+			ExpectError:       true,
+		},
+		{
+			MaxEventsPerBatch: pointer.To(int64(5001)),
+			ExpectError:       true,
+		},
+		{
+			PreferredBatchSizeInKilobytes: pointer.To(int64(0)),
+			ExpectError:                   true,
+		},
+		{
+			PreferredBatchSizeInKilobytes: pointer.To(int64(1025)),
+			ExpectError:                   true,
+		},
+	}
+
+	for _, v := range testData {
+		err := ValidateWebHookEventSubscriptionDestinationBatching(v.MaxEventsPerBatch, v.PreferredBatchSizeInKilobytes)
+		if v.ExpectError && err == nil {
+			t.Fatalf("expected an error for %+v but didn't get one", v)
+		}
+		if !v.ExpectError && err != nil {
+			t.Fatalf("expected no error for %+v but got: %+v", v, err)
+		}
+	}
+}
+
+func TestExpandEventGridEventSubscriptionWebhookEndpoint(t *testing.T) {
+	testData := []struct {
+		Name        string
+		Input       map[string]interface{}
+		ExpectError bool
+	}{
+		{
+			Name: "Without Authentication",
+			Input: map[string]interface{}{
+				"url":                            "https://example.com/webhook",
+				"active_directory_tenant_id":     "",
+				"active_directory_app_id_or_uri": "",
+			},
+		},
+		{
+			Name: "With Authentication",
+			Input: map[string]interface{}{
+				"url":                            "https://example.com/webhook",
+				"active_directory_tenant_id":     "00000000-0000-0000-0000-000000000000",
+				"active_directory_app_id_or_uri": "api://example",
+			},
+		},
+		{
+			Name: "Tenant Without Application",
+			Input: map[string]interface{}{
+				"url":                            "https://example.com/webhook",
+				"active_directory_tenant_id":     "00000000-0000-0000-0000-000000000000",
+				"active_directory_app_id_or_uri": "",
+			},
+			ExpectError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.Name)
+
+		deliveryMappings := []eventsubscriptions.DeliveryAttributeMapping{}
+		destination, err := expandEventGridEventSubscriptionWebhookEndpoint([]interface{}{v.Input}, deliveryMappings)
+		if err != nil {
+			if v.ExpectError {
+				continue
+			}
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+		if v.ExpectError {
+			t.Fatalf("expected an error but didn't get one")
+		}
+
+		webhook, ok := destination.(*eventsubscriptions.WebHookEventSubscriptionDestination)
+		if !ok || webhook.Properties == nil {
+			t.Fatalf("expected a WebHook destination but got %+v", destination)
+		}
+		if webhook.Properties.DeliveryAttributeMappings == nil {
+			t.Fatalf("expected the delivery attribute mappings to be set")
+		}
+		if actual := pointer.From(webhook.Properties.AzureActiveDirectoryTenantId); actual != v.Input["active_directory_tenant_id"] {
+			t.Fatalf("expected the tenant ID to be %q but got %q", v.Input["active_directory_tenant_id"], actual)
+		}
+	}
+}
@@ -39,6 +39,8 @@ func resourceEventGridDomain() *pluginsdk.Resource {
 			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
 		},
 
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(inputMappingCustomizeDiff),
+
 		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
 			_, err := domains.ParseDomainID(id)
 			return err
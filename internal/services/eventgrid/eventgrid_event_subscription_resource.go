@@ -7,15 +7,19 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/eventgrid/2022-06-15/domains"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/eventgrid/2022-06-15/domaintopics"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/eventgrid/2022-06-15/eventsubscriptions"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
-	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
 )
@@ -79,7 +83,7 @@ func resourceEventGridEventSubscription() *pluginsdk.Resource {
 				Type:         pluginsdk.TypeString,
 				Required:     true,
 				ForceNew:     true,
-				ValidateFunc: validation.StringIsNotEmpty,
+				ValidateFunc: validateEventSubscriptionScope,
 			},
 
 			"event_delivery_schema": eventSubscriptionSchemaEventDeliverySchema(),
@@ -137,6 +141,8 @@ func resourceEventGridEventSubscription() *pluginsdk.Resource {
 				),
 			),
 
+			"destination_type": eventSubscriptionSchemaDestinationType(),
+
 			"included_event_types": eventSubscriptionSchemaIncludedEventTypes(),
 
 			"subject_filter": eventSubscriptionSchemaSubjectFilter(),
@@ -177,9 +183,16 @@ func resourceEventGridEventSubscriptionCreateUpdate(d *pluginsdk.ResourceData, m
 		if !response.WasNotFound(existing.HttpResponse) {
 			return tf.ImportAsExistsError("azurerm_eventgrid_event_subscription", id.ID())
 		}
+
+		if err := validateEventSubscriptionDomainTopicScope(ctx, meta, id.Scope); err != nil {
+			return err
+		}
 	}
 
-	destination := expandEventSubscriptionDestination(d)
+	destination, err := expandEventSubscriptionDestination(d)
+	if err != nil {
+		return fmt.Errorf("expanding the endpoint for %s: %+v", id, err)
+	}
 	if destination == nil {
 		return fmt.Errorf("one of the following endpoint types must be specificed to create an EventGrid Event Subscription: %q", possibleEventSubscriptionEndpointTypes())
 	}
@@ -189,7 +202,17 @@ func resourceEventGridEventSubscriptionCreateUpdate(d *pluginsdk.ResourceData, m
 		return fmt.Errorf("expanding filters for %s: %+v", id, err)
 	}
 
-	deadLetterDestination := expandEventSubscriptionStorageBlobDeadLetterDestination(d)
+	deadLetterDestination, err := expandEventSubscriptionStorageBlobDeadLetterDestination(d)
+	if err != nil {
+		return fmt.Errorf("expanding `storage_blob_dead_letter_destination`: %+v", err)
+	}
+	if warning := eventSubscriptionDeadLetterDestinationWarning(destination, deadLetterDestination); warning != "" {
+		log.Printf("[WARN] %s", warning)
+	}
+
+	if err := ensureEventSubscriptionDeadLetterContainer(ctx, meta, d.Get("storage_blob_dead_letter_destination").([]interface{})); err != nil {
+		return err
+	}
 
 	properties := eventsubscriptions.EventSubscriptionProperties{
 		ExpirationTimeUtc:   pointer.To(d.Get("expiration_time_utc").(string)),
@@ -275,7 +298,7 @@ func resourceEventGridEventSubscriptionRead(d *pluginsdk.ResourceData, meta inte
 	}
 
 	d.Set("name", id.EventSubscriptionName)
-	d.Set("scope", id.Scope)
+	d.Set("scope", flattenEventSubscriptionScope(id.Scope, d.Get("scope").(string)))
 
 	if model := resp.Model; model != nil {
 		if props := model.Properties; props != nil {
@@ -295,12 +318,10 @@ func resourceEventGridEventSubscriptionRead(d *pluginsdk.ResourceData, meta inte
 			d.Set("event_delivery_schema", eventDeliverySchema)
 
 			destination := props.Destination
-			deliveryIdentityFlattened := make([]interface{}, 0)
 			if deliveryIdentity := props.DeliveryWithResourceIdentity; deliveryIdentity != nil {
 				destination = deliveryIdentity.Destination
-				deliveryIdentityFlattened = flattenEventSubscriptionIdentity(deliveryIdentity.Identity)
 			}
-			if err := d.Set("delivery_identity", deliveryIdentityFlattened); err != nil {
+			if err := d.Set("delivery_identity", flattenEventSubscriptionDeliveryIdentity(props.DeliveryWithResourceIdentity)); err != nil {
 				return fmt.Errorf("setting `delivery_identity` for %s: %+v", *id, err)
 			}
 
@@ -314,6 +335,7 @@ func resourceEventGridEventSubscriptionRead(d *pluginsdk.ResourceData, meta inte
 				return fmt.Errorf("setting `azure_function_endpoint` for %s: %+v", *id, err)
 			}
 
+			d.Set("destination_type", flattenEventSubscriptionDestinationType(destination))
 			d.Set("eventhub_endpoint_id", flattenEventSubscriptionDestinationEventHub(destination))
 			d.Set("hybrid_connection_endpoint_id", flattenEventSubscriptionDestinationHybridConnection(destination))
 			d.Set("service_bus_queue_endpoint_id", flattenEventSubscriptionDestinationServiceBusQueueEndpoint(destination))
@@ -326,15 +348,13 @@ func resourceEventGridEventSubscriptionRead(d *pluginsdk.ResourceData, meta inte
 			}
 
 			deadLetterDestination := props.DeadLetterDestination
-			deadLetterIdentityFlattened := make([]interface{}, 0)
 			if deadLetterIdentity := props.DeadLetterWithResourceIdentity; deadLetterIdentity != nil {
 				deadLetterDestination = deadLetterIdentity.DeadLetterDestination
-				deadLetterIdentityFlattened = flattenEventSubscriptionIdentity(deadLetterIdentity.Identity)
 			}
-			if err := d.Set("dead_letter_identity", deadLetterIdentityFlattened); err != nil {
+			if err := d.Set("dead_letter_identity", flattenEventSubscriptionDeadLetterIdentity(props.DeadLetterWithResourceIdentity)); err != nil {
 				return fmt.Errorf("setting `dead_letter_identity` for %s: %+v", *id, err)
 			}
-			if err := d.Set("storage_blob_dead_letter_destination", flattenEventSubscriptionStorageBlobDeadLetterDestination(deadLetterDestination)); err != nil {
+			if err := d.Set("storage_blob_dead_letter_destination", flattenEventSubscriptionStorageBlobDeadLetterDestination(deadLetterDestination, d.Get("storage_blob_dead_letter_destination").([]interface{}))); err != nil {
 				return fmt.Errorf("setting `storage_blob_dead_letter_destination` for %s: %+v", *id, err)
 			}
 
@@ -346,7 +366,7 @@ func resourceEventGridEventSubscriptionRead(d *pluginsdk.ResourceData, meta inte
 			}
 			d.Set("advanced_filtering_on_arrays_enabled", enableAdvancedFilteringOnArrays)
 			d.Set("included_event_types", includedEventTypes)
-			if err := d.Set("advanced_filter", flattenEventSubscriptionAdvancedFilter(props.Filter)); err != nil {
+			if err := d.Set("advanced_filter", flattenEventSubscriptionAdvancedFilter(props.Filter, d.Get("advanced_filter").([]interface{}))); err != nil {
 				return fmt.Errorf("setting `advanced_filter` for %s: %+v", *id, err)
 			}
 			if err := d.Set("retry_policy", flattenEventSubscriptionRetryPolicy(props.RetryPolicy)); err != nil {
@@ -379,3 +399,72 @@ func resourceEventGridEventSubscriptionDelete(d *pluginsdk.ResourceData, meta in
 
 	return nil
 }
+
+// validateEventSubscriptionScope validates the scope of the Event Subscription, which can be any Resource ID - such as a
+// Management Group, a Subscription, a Resource Group or a Resource
+func validateEventSubscriptionScope(input interface{}, key string) (warnings []string, errors []error) {
+	v, ok := input.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", key))
+		return
+	}
+
+	if _, err := azure.ParseAzureResourceID(v); err == nil {
+		return
+	}
+	// scopes outside of a Subscription (e.g. a Management Group) begin with `/providers/`
+	if id, err := azure.ParseAzureResourceIDWithoutSubscription(v); err == nil && id.Provider != "" {
+		return
+	}
+
+	errors = append(errors, fmt.Errorf("expected %q to be a Resource ID, such as the ID of a Management Group, Subscription, Resource Group or Resource, but got %q", key, v))
+	return
+}
+
+// flattenEventSubscriptionScope returns the scope of the Event Subscription, which the API doesn't return with consistent
+// casing - so the existing value is retained when it only differs in casing, otherwise the casing of Management Group
+// and Subscription scopes is normalized and other scopes are returned as-is
+func flattenEventSubscriptionScope(input, existing string) string {
+	if strings.EqualFold(input, existing) {
+		return existing
+	}
+
+	if id, err := commonids.ParseManagementGroupIDInsensitively(input); err == nil {
+		return id.ID()
+	}
+	if id, err := commonids.ParseSubscriptionIDInsensitively(input); err == nil {
+		return id.ID()
+	}
+
+	return input
+}
+
+// validateEventSubscriptionDomainTopicScope ensures that when the Event Subscription is scoped to a Domain Topic, either
+// the Domain Topic exists or the Domain will create it when the first Event Subscription is created
+func validateEventSubscriptionDomainTopicScope(ctx context.Context, meta interface{}, scope string) error {
+	domainTopicId, err := domaintopics.ParseDomainTopicIDInsensitively(scope)
+	if err != nil {
+		// the Event Subscription isn't scoped to a Domain Topic
+		return nil
+	}
+
+	existing, err := meta.(*clients.Client).EventGrid.DomainTopics.Get(ctx, *domainTopicId)
+	if err == nil {
+		return nil
+	}
+	if !response.WasNotFound(existing.HttpResponse) {
+		return fmt.Errorf("retrieving %s: %+v", *domainTopicId, err)
+	}
+
+	domainId := domains.NewDomainID(domainTopicId.SubscriptionId, domainTopicId.ResourceGroupName, domainTopicId.DomainName)
+	domain, err := meta.(*clients.Client).EventGrid.Domains.Get(ctx, domainId)
+	if err != nil {
+		return fmt.Errorf("retrieving %s: %+v", domainId, err)
+	}
+
+	if model := domain.Model; model != nil && model.Properties != nil && pointer.From(model.Properties.AutoCreateTopicWithFirstSubscription) {
+		return nil
+	}
+
+	return fmt.Errorf("%s was not found and %s doesn't create Domain Topics automatically - either create the Domain Topic first or set `auto_create_topic_with_first_subscription` to `true` on the Domain", *domainTopicId, domainId)
+}
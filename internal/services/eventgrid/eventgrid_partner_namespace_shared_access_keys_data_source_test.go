@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package eventgrid_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type EventGridPartnerNamespaceSharedAccessKeysDataSource struct{}
+
+func TestAccEventGridPartnerNamespaceSharedAccessKeysDataSource_basic(t *testing.T) {
+	// Partner Namespaces require an approved Partner Registration, so this requires an existing Partner Namespace
+	if os.Getenv("ARM_TEST_EVENTGRID_PARTNER_NAMESPACE_NAME") == "" || os.Getenv("ARM_TEST_DATA_RESOURCE_GROUP") == "" {
+		t.Skip("Skipping as either ARM_TEST_EVENTGRID_PARTNER_NAMESPACE_NAME or ARM_TEST_DATA_RESOURCE_GROUP was not specified")
+	}
+
+	data := acceptance.BuildTestData(t, "data.azurerm_eventgrid_partner_namespace_shared_access_keys", "test")
+	r := EventGridPartnerNamespaceSharedAccessKeysDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("primary_key").Exists(),
+				check.That(data.ResourceName).Key("secondary_key").Exists(),
+			),
+		},
+	})
+}
+
+func (EventGridPartnerNamespaceSharedAccessKeysDataSource) basic() string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+data "azurerm_eventgrid_partner_namespace_shared_access_keys" "test" {
+  name                = "%s"
+  resource_group_name = "%s"
+}
+`, os.Getenv("ARM_TEST_EVENTGRID_PARTNER_NAMESPACE_NAME"), os.Getenv("ARM_TEST_DATA_RESOURCE_GROUP"))
+}
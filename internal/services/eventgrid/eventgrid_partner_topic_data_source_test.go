@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package eventgrid_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type EventGridPartnerTopicDataSource struct{}
+
+func TestAccEventGridPartnerTopicDataSource_basic(t *testing.T) {
+	// Partner Topics are created by the Partner, rather than by the subscriber, so this requires an existing Partner Topic
+	if os.Getenv("ARM_TEST_EVENTGRID_PARTNER_TOPIC_NAME") == "" || os.Getenv("ARM_TEST_DATA_RESOURCE_GROUP") == "" {
+		t.Skip("Skipping as either ARM_TEST_EVENTGRID_PARTNER_TOPIC_NAME or ARM_TEST_DATA_RESOURCE_GROUP was not specified")
+	}
+
+	data := acceptance.BuildTestData(t, "data.azurerm_eventgrid_partner_topic", "test")
+	r := EventGridPartnerTopicDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("location").Exists(),
+				check.That(data.ResourceName).Key("activation_state").Exists(),
+				check.That(data.ResourceName).Key("partner_registration_immutable_id").Exists(),
+				check.That(data.ResourceName).Key("source").Exists(),
+			),
+		},
+	})
+}
+
+func (EventGridPartnerTopicDataSource) basic() string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+data "azurerm_eventgrid_partner_topic" "test" {
+  name                = "%s"
+  resource_group_name = "%s"
+}
+`, os.Getenv("ARM_TEST_EVENTGRID_PARTNER_TOPIC_NAME"), os.Getenv("ARM_TEST_DATA_RESOURCE_GROUP"))
+}
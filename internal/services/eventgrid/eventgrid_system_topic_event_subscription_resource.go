@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/go-azure-helpers/lang/response"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/eventgrid/2022-06-15/eventsubscriptions"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/eventgrid/2022-06-15/systemtopics"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
@@ -119,6 +120,8 @@ func resourceEventGridSystemTopicEventSubscription() *pluginsdk.Resource {
 				),
 			),
 
+			"destination_type": eventSubscriptionSchemaDestinationType(),
+
 			"included_event_types": eventSubscriptionSchemaIncludedEventTypes(),
 
 			"subject_filter": eventSubscriptionSchemaSubjectFilter(),
@@ -162,7 +165,10 @@ func resourceEventGridSystemTopicEventSubscriptionCreateUpdate(d *pluginsdk.Reso
 		}
 	}
 
-	destination := expandEventSubscriptionDestination(d)
+	destination, err := expandEventSubscriptionDestination(d)
+	if err != nil {
+		return fmt.Errorf("expanding the endpoint for %s: %+v", id, err)
+	}
 	if destination == nil {
 		return fmt.Errorf("one of the following endpoint types must be specificed to create an EventGrid System Topic Event Subscription: %q", possibleSystemTopicEventSubscriptionEndpointTypes())
 	}
@@ -172,7 +178,24 @@ func resourceEventGridSystemTopicEventSubscriptionCreateUpdate(d *pluginsdk.Reso
 		return fmt.Errorf("expanding `filters`: %+v", err)
 	}
 
-	deadLetterDestination := expandEventSubscriptionStorageBlobDeadLetterDestination(d)
+	if d.HasChange("included_event_types") {
+		systemTopicId := systemtopics.NewSystemTopicID(id.SubscriptionId, id.ResourceGroupName, id.SystemTopicName)
+		if err := checkSystemTopicEventSubscriptionIncludedEventTypes(ctx, meta, systemTopicId, pointer.From(filter.IncludedEventTypes)); err != nil {
+			return fmt.Errorf("validating `included_event_types` for %s: %+v", id, err)
+		}
+	}
+
+	deadLetterDestination, err := expandEventSubscriptionStorageBlobDeadLetterDestination(d)
+	if err != nil {
+		return fmt.Errorf("expanding `storage_blob_dead_letter_destination`: %+v", err)
+	}
+	if warning := eventSubscriptionDeadLetterDestinationWarning(destination, deadLetterDestination); warning != "" {
+		log.Printf("[WARN] %s", warning)
+	}
+
+	if err := ensureEventSubscriptionDeadLetterContainer(ctx, meta, d.Get("storage_blob_dead_letter_destination").([]interface{})); err != nil {
+		return err
+	}
 
 	eventSubscriptionProperties := eventsubscriptions.EventSubscriptionProperties{
 		Filter:              filter,
@@ -274,12 +297,10 @@ func resourceEventGridSystemTopicEventSubscriptionRead(d *pluginsdk.ResourceData
 			d.Set("event_delivery_schema", string(pointer.From(props.EventDeliverySchema)))
 
 			destination := props.Destination
-			deliveryIdentityFlattened := make([]interface{}, 0)
 			if deliveryIdentity := props.DeliveryWithResourceIdentity; deliveryIdentity != nil {
 				destination = deliveryIdentity.Destination
-				deliveryIdentityFlattened = flattenEventSubscriptionIdentity(deliveryIdentity.Identity)
 			}
-			if err := d.Set("delivery_identity", deliveryIdentityFlattened); err != nil {
+			if err := d.Set("delivery_identity", flattenEventSubscriptionDeliveryIdentity(props.DeliveryWithResourceIdentity)); err != nil {
 				return fmt.Errorf("setting `delivery_identity`: %+v", err)
 			}
 
@@ -293,6 +314,7 @@ func resourceEventGridSystemTopicEventSubscriptionRead(d *pluginsdk.ResourceData
 				return fmt.Errorf("setting `azure_function_endpoint` for %s: %+v", *id, err)
 			}
 
+			d.Set("destination_type", flattenEventSubscriptionDestinationType(destination))
 			d.Set("eventhub_endpoint_id", flattenEventSubscriptionDestinationEventHub(destination))
 			d.Set("hybrid_connection_endpoint_id", flattenEventSubscriptionDestinationHybridConnection(destination))
 			d.Set("service_bus_queue_endpoint_id", flattenEventSubscriptionDestinationServiceBusQueueEndpoint(destination))
@@ -305,15 +327,13 @@ func resourceEventGridSystemTopicEventSubscriptionRead(d *pluginsdk.ResourceData
 			}
 
 			deadLetterDestination := props.DeadLetterDestination
-			deadLetterIdentityFlattened := make([]interface{}, 0)
 			if deadLetterIdentity := props.DeadLetterWithResourceIdentity; deadLetterIdentity != nil {
 				deadLetterDestination = deadLetterIdentity.DeadLetterDestination
-				deadLetterIdentityFlattened = flattenEventSubscriptionIdentity(deadLetterIdentity.Identity)
 			}
-			if err := d.Set("dead_letter_identity", deadLetterIdentityFlattened); err != nil {
+			if err := d.Set("dead_letter_identity", flattenEventSubscriptionDeadLetterIdentity(props.DeadLetterWithResourceIdentity)); err != nil {
 				return fmt.Errorf("setting `dead_letter_identity`: %+v", err)
 			}
-			if err := d.Set("storage_blob_dead_letter_destination", flattenEventSubscriptionStorageBlobDeadLetterDestination(deadLetterDestination)); err != nil {
+			if err := d.Set("storage_blob_dead_letter_destination", flattenEventSubscriptionStorageBlobDeadLetterDestination(deadLetterDestination, d.Get("storage_blob_dead_letter_destination").([]interface{}))); err != nil {
 				return fmt.Errorf("setting `storage_blob_dead_letter_destination`: %+v", err)
 			}
 
@@ -325,7 +345,7 @@ func resourceEventGridSystemTopicEventSubscriptionRead(d *pluginsdk.ResourceData
 			}
 			d.Set("advanced_filtering_on_arrays_enabled", enableAdvancedFilteringOnArrays)
 			d.Set("included_event_types", includedEventTypes)
-			if err := d.Set("advanced_filter", flattenEventSubscriptionAdvancedFilter(props.Filter)); err != nil {
+			if err := d.Set("advanced_filter", flattenEventSubscriptionAdvancedFilter(props.Filter, d.Get("advanced_filter").([]interface{}))); err != nil {
 				return fmt.Errorf("setting `advanced_filter` for %s: %+v", *id, err)
 			}
 			if err := d.Set("retry_policy", flattenEventSubscriptionRetryPolicy(props.RetryPolicy)); err != nil {
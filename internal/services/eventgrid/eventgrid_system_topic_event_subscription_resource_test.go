@@ -63,6 +63,7 @@ func TestAccEventGridSystemTopicEventSubscription_eventHubID(t *testing.T) {
 				check.That(data.ResourceName).ExistsInAzure(r),
 				check.That(data.ResourceName).Key("event_delivery_schema").HasValue("CloudEventSchemaV1_0"),
 				check.That(data.ResourceName).Key("eventhub_endpoint_id").Exists(),
+				check.That(data.ResourceName).Key("destination_type").HasValue("EventHub"),
 			),
 		},
 		data.ImportStep(),
@@ -114,6 +115,7 @@ func TestAccEventGridSystemTopicEventSubscription_update(t *testing.T) {
 				check.That(data.ResourceName).ExistsInAzure(r),
 				check.That(data.ResourceName).Key("event_delivery_schema").HasValue("EventGridSchema"),
 				check.That(data.ResourceName).Key("storage_queue_endpoint.#").HasValue("1"),
+				check.That(data.ResourceName).Key("destination_type").HasValue("StorageQueue"),
 				check.That(data.ResourceName).Key("storage_blob_dead_letter_destination.#").HasValue("1"),
 				check.That(data.ResourceName).Key("included_event_types.0").HasValue("Microsoft.Resources.ResourceWriteSuccess"),
 				check.That(data.ResourceName).Key("retry_policy.0.max_delivery_attempts").HasValue("11"),
@@ -6,6 +6,7 @@ package eventgrid_test
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/go-azure-sdk/resource-manager/eventgrid/2022-06-15/topics"
@@ -109,6 +110,18 @@ func TestAccEventGridTopic_mapping(t *testing.T) {
 	})
 }
 
+func TestAccEventGridTopic_mappingWithoutCustomSchema(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_eventgrid_topic", "test")
+	r := EventGridTopicResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.mappingWithoutCustomSchema(data),
+			ExpectError: regexp.MustCompile("`input_mapping_fields` can only be specified when `input_schema` is set to \"CustomEventSchema\""),
+		},
+	})
+}
+
 func TestAccEventGridTopic_basicWithTags(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_eventgrid_topic", "test")
 	r := EventGridTopicResource{}
@@ -295,6 +308,29 @@ resource "azurerm_eventgrid_topic" "test" {
 `, data.RandomInteger, data.Locations.Primary, data.RandomInteger)
 }
 
+func (EventGridTopicResource) mappingWithoutCustomSchema(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_eventgrid_topic" "test" {
+  name                = "acctesteg-%d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+  input_schema        = "EventGridSchema"
+  input_mapping_fields {
+    subject = "subject"
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger)
+}
+
 func (EventGridTopicResource) basicWithTags(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package eventgrid
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-azure-sdk/resource-manager/eventgrid/2022-06-15/topics"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// inputMappingCustomizeDiff validates the `input_mapping_fields` and `input_mapping_default_values` of an EventGrid
+// Topic or Domain against the `input_schema`, since these are only used when events are published in a custom schema
+func inputMappingCustomizeDiff(_ context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("input_schema") {
+		return nil
+	}
+
+	return validateInputMapping(d.Get("input_schema").(string), d.Get("input_mapping_fields").([]interface{}), d.Get("input_mapping_default_values").([]interface{}))
+}
+
+func validateInputMapping(inputSchema string, inputMappingFields []interface{}, inputMappingDefaultValues []interface{}) error {
+	if inputSchema == string(topics.InputSchemaCustomEventSchema) {
+		return nil
+	}
+
+	if len(inputMappingFields) > 0 {
+		return fmt.Errorf("`input_mapping_fields` can only be specified when `input_schema` is set to %q", string(topics.InputSchemaCustomEventSchema))
+	}
+
+	if len(inputMappingDefaultValues) > 0 {
+		return fmt.Errorf("`input_mapping_default_values` can only be specified when `input_schema` is set to %q", string(topics.InputSchemaCustomEventSchema))
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package eventgrid
+
+import (
+	"testing"
+)
+
+func TestValidateInputMapping(t *testing.T) {
+	fields := []interface{}{
+		map[string]interface{}{
+			"event_type": "eventType",
+			"subject":    "subject",
+		},
+	}
+	defaultValues := []interface{}{
+		map[string]interface{}{
+			"data_version": "1.0",
+		},
+	}
+
+	testData := []struct {
+		Name          string
+		InputSchema   string
+		Fields        []interface{}
+		DefaultValues []interface{}
+		ShouldError   bool
+	}{
+		{
+			Name:        "EventGrid schema without mappings",
+			InputSchema: "EventGridSchema",
+		},
+		{
+			Name:          "custom schema with field mappings and default values",
+			InputSchema:   "CustomEventSchema",
+			Fields:        fields,
+			DefaultValues: defaultValues,
+		},
+		{
+			Name:        "custom schema with only field mappings",
+			InputSchema: "CustomEventSchema",
+			Fields:      fields,
+		},
+		{
+			Name:          "custom schema with only default values",
+			InputSchema:   "CustomEventSchema",
+			DefaultValues: defaultValues,
+		},
+		{
+			Name:        "EventGrid schema with field mappings",
+			InputSchema: "EventGridSchema",
+			Fields:      fields,
+			ShouldError: true,
+		},
+		{
+			Name:          "Cloud Event schema with default values",
+			InputSchema:   "CloudEventSchemaV1_0",
+			DefaultValues: defaultValues,
+			ShouldError:   true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		err := validateInputMapping(v.InputSchema, v.Fields, v.DefaultValues)
+		if v.ShouldError && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !v.ShouldError && err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+	}
+}
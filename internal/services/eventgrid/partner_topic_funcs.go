@@ -0,0 +1,14 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package eventgrid
+
+import (
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/identity"
+)
+
+// FlattenPartnerTopicIdentity flattens the Identity of a Partner Topic into the `identity` block, exposing
+// the `principal_id` and `tenant_id` of the System Assigned Identity where present.
+func FlattenPartnerTopicIdentity(input *identity.SystemAndUserAssignedMap) (*[]interface{}, error) {
+	return identity.FlattenSystemAndUserAssignedMap(input)
+}
@@ -31,10 +31,12 @@ func (r Registration) WebsiteCategories() []string {
 // SupportedDataSources returns the supported Data Sources supported by this Service
 func (r Registration) SupportedDataSources() map[string]*pluginsdk.Resource {
 	return map[string]*pluginsdk.Resource{
-		"azurerm_eventgrid_topic":        dataSourceEventGridTopic(),
-		"azurerm_eventgrid_domain":       dataSourceEventGridDomain(),
-		"azurerm_eventgrid_domain_topic": dataSourceEventGridDomainTopic(),
-		"azurerm_eventgrid_system_topic": dataSourceEventGridSystemTopic(),
+		"azurerm_eventgrid_topic":                                dataSourceEventGridTopic(),
+		"azurerm_eventgrid_domain":                               dataSourceEventGridDomain(),
+		"azurerm_eventgrid_domain_topic":                         dataSourceEventGridDomainTopic(),
+		"azurerm_eventgrid_system_topic":                         dataSourceEventGridSystemTopic(),
+		"azurerm_eventgrid_partner_namespace_shared_access_keys": dataSourceEventGridPartnerNamespaceSharedAccessKeys(),
+		"azurerm_eventgrid_partner_topic":                        dataSourceEventGridPartnerTopic(),
 	}
 }
 
@@ -76,3 +76,59 @@ func VirtualNetworkPeeringID(input string) (*VirtualNetworkPeeringId, error) {
 
 	return &resourceId, nil
 }
+
+// VirtualNetworkPeeringIDInsensitively parses an VirtualNetworkPeering ID into an VirtualNetworkPeeringId struct, insensitively
+// This should only be used to parse an ID for rewriting, the VirtualNetworkPeeringID
+// method should be used instead for validation etc.
+//
+// Whilst this may seem strange, this enables Terraform have consistent casing
+// which works around issues in Core, whilst handling broken API responses.
+func VirtualNetworkPeeringIDInsensitively(input string) (*VirtualNetworkPeeringId, error) {
+	id, err := resourceids.ParseAzureResourceID(input)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceId := VirtualNetworkPeeringId{
+		SubscriptionId: id.SubscriptionID,
+		ResourceGroup:  id.ResourceGroup,
+	}
+
+	if resourceId.SubscriptionId == "" {
+		return nil, fmt.Errorf("ID was missing the 'subscriptions' element")
+	}
+
+	if resourceId.ResourceGroup == "" {
+		return nil, fmt.Errorf("ID was missing the 'resourceGroups' element")
+	}
+
+	// find the correct casing for the 'virtualNetworks' segment
+	virtualNetworksKey := "virtualNetworks"
+	for key := range id.Path {
+		if strings.EqualFold(key, virtualNetworksKey) {
+			virtualNetworksKey = key
+			break
+		}
+	}
+	if resourceId.VirtualNetworkName, err = id.PopSegment(virtualNetworksKey); err != nil {
+		return nil, err
+	}
+
+	// find the correct casing for the 'virtualNetworkPeerings' segment
+	virtualNetworkPeeringsKey := "virtualNetworkPeerings"
+	for key := range id.Path {
+		if strings.EqualFold(key, virtualNetworkPeeringsKey) {
+			virtualNetworkPeeringsKey = key
+			break
+		}
+	}
+	if resourceId.Name, err = id.PopSegment(virtualNetworkPeeringsKey); err != nil {
+		return nil, err
+	}
+
+	if err := id.ValidateNoEmptySegments(input); err != nil {
+		return nil, err
+	}
+
+	return &resourceId, nil
+}
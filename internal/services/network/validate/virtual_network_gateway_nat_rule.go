@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/tombuildsstuff/kermit/sdk/network/2022-07-01/network"
+)
+
+// VirtualNetworkGatewayNatRule validates the combination of the mode, type and mappings of a Virtual Network Gateway
+// NAT Rule, which would otherwise only be rejected by the API. Address spaces which aren't known yet (e.g. at plan
+// time) are skipped.
+func VirtualNetworkGatewayNatRule(input network.VirtualNetworkGatewayNatRuleProperties) error {
+	switch input.Mode {
+	case network.VpnNatRuleModeEgressSnat, network.VpnNatRuleModeIngressSnat:
+	default:
+		return fmt.Errorf("`mode` must be one of %q or %q but got %q", network.VpnNatRuleModeEgressSnat, network.VpnNatRuleModeIngressSnat, input.Mode)
+	}
+
+	internalMappings := make([]network.VpnNatRuleMapping, 0)
+	if input.InternalMappings != nil {
+		internalMappings = *input.InternalMappings
+	}
+	externalMappings := make([]network.VpnNatRuleMapping, 0)
+	if input.ExternalMappings != nil {
+		externalMappings = *input.ExternalMappings
+	}
+
+	if len(internalMappings) == 0 || len(externalMappings) == 0 {
+		return fmt.Errorf("at least one `internal_mapping` and one `external_mapping` must be specified")
+	}
+
+	switch input.Type {
+	case network.VpnNatRuleTypeDynamic:
+		// Dynamic NAT translates many addresses to a pool of addresses, so the mappings don't need to line up
+		return nil
+	case network.VpnNatRuleTypeStatic:
+	default:
+		return fmt.Errorf("`type` must be one of %q or %q but got %q", network.VpnNatRuleTypeStatic, network.VpnNatRuleTypeDynamic, input.Type)
+	}
+
+	// Static NAT is a 1:1 mapping, so each `internal_mapping` must translate to an `external_mapping` of the same size
+	if len(internalMappings) != len(externalMappings) {
+		return fmt.Errorf("a `Static` NAT Rule must have the same number of `internal_mapping` and `external_mapping` blocks but got %d and %d", len(internalMappings), len(externalMappings))
+	}
+
+	for i := range internalMappings {
+		internal := ""
+		if v := internalMappings[i].AddressSpace; v != nil {
+			internal = *v
+		}
+		external := ""
+		if v := externalMappings[i].AddressSpace; v != nil {
+			external = *v
+		}
+		if internal == "" || external == "" {
+			continue
+		}
+
+		_, internalNetwork, err := net.ParseCIDR(internal)
+		if err != nil {
+			return fmt.Errorf("parsing `internal_mapping.%d.address_space` %q: %+v", i, internal, err)
+		}
+		_, externalNetwork, err := net.ParseCIDR(external)
+		if err != nil {
+			return fmt.Errorf("parsing `external_mapping.%d.address_space` %q: %+v", i, external, err)
+		}
+
+		internalSize, _ := internalNetwork.Mask.Size()
+		externalSize, _ := externalNetwork.Mask.Size()
+		if internalSize != externalSize {
+			return fmt.Errorf("a `Static` NAT Rule must map address spaces of the same size, but `internal_mapping.%d.address_space` is %q and `external_mapping.%d.address_space` is %q", i, internal, i, external)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/kermit/sdk/network/2022-07-01/network"
+)
+
+func TestVirtualNetworkGatewayNatRule(t *testing.T) {
+	mappings := func(addressSpaces ...string) *[]network.VpnNatRuleMapping {
+		result := make([]network.VpnNatRuleMapping, 0)
+		for _, v := range addressSpaces {
+			result = append(result, network.VpnNatRuleMapping{
+				AddressSpace: utils.String(v),
+			})
+		}
+		return &result
+	}
+
+	testData := []struct {
+		Name  string
+		Input network.VirtualNetworkGatewayNatRuleProperties
+		Valid bool
+	}{
+		{
+			Name: "EgressSnat Static with matching sizes",
+			Input: network.VirtualNetworkGatewayNatRuleProperties{
+				Mode:             network.VpnNatRuleModeEgressSnat,
+				Type:             network.VpnNatRuleTypeStatic,
+				InternalMappings: mappings("10.4.0.0/24"),
+				ExternalMappings: mappings("192.168.21.0/24"),
+			},
+			Valid: true,
+		},
+		{
+			Name: "IngressSnat Static with multiple matching sizes",
+			Input: network.VirtualNetworkGatewayNatRuleProperties{
+				Mode:             network.VpnNatRuleModeIngressSnat,
+				Type:             network.VpnNatRuleTypeStatic,
+				InternalMappings: mappings("10.4.0.0/24", "10.5.0.0/26"),
+				ExternalMappings: mappings("192.168.21.0/24", "192.168.22.0/26"),
+			},
+			Valid: true,
+		},
+		{
+			Name: "Static with different sizes",
+			Input: network.VirtualNetworkGatewayNatRuleProperties{
+				Mode:             network.VpnNatRuleModeEgressSnat,
+				Type:             network.VpnNatRuleTypeStatic,
+				InternalMappings: mappings("10.4.0.0/16"),
+				ExternalMappings: mappings("192.168.21.0/24"),
+			},
+			Valid: false,
+		},
+		{
+			Name: "Static with a different number of mappings",
+			Input: network.VirtualNetworkGatewayNatRuleProperties{
+				Mode:             network.VpnNatRuleModeIngressSnat,
+				Type:             network.VpnNatRuleTypeStatic,
+				InternalMappings: mappings("10.4.0.0/24", "10.5.0.0/24"),
+				ExternalMappings: mappings("192.168.21.0/24"),
+			},
+			Valid: false,
+		},
+		{
+			Name: "Static with an address space which isn't known yet",
+			Input: network.VirtualNetworkGatewayNatRuleProperties{
+				Mode:             network.VpnNatRuleModeEgressSnat,
+				Type:             network.VpnNatRuleTypeStatic,
+				InternalMappings: mappings(""),
+				ExternalMappings: mappings("192.168.21.0/24"),
+			},
+			Valid: true,
+		},
+		{
+			Name: "EgressSnat Dynamic with different sizes",
+			Input: network.VirtualNetworkGatewayNatRuleProperties{
+				Mode:             network.VpnNatRuleModeEgressSnat,
+				Type:             network.VpnNatRuleTypeDynamic,
+				InternalMappings: mappings("10.4.0.0/16", "10.5.0.0/16"),
+				ExternalMappings: mappings("192.168.21.0/24"),
+			},
+			Valid: true,
+		},
+		{
+			Name: "Dynamic without an external mapping",
+			Input: network.VirtualNetworkGatewayNatRuleProperties{
+				Mode:             network.VpnNatRuleModeIngressSnat,
+				Type:             network.VpnNatRuleTypeDynamic,
+				InternalMappings: mappings("10.4.0.0/16"),
+			},
+			Valid: false,
+		},
+		{
+			Name: "invalid mode",
+			Input: network.VirtualNetworkGatewayNatRuleProperties{
+				Mode:             network.VpnNatRuleMode("Snat"),
+				Type:             network.VpnNatRuleTypeStatic,
+				InternalMappings: mappings("10.4.0.0/24"),
+				ExternalMappings: mappings("192.168.21.0/24"),
+			},
+			Valid: false,
+		},
+		{
+			Name: "invalid type",
+			Input: network.VirtualNetworkGatewayNatRuleProperties{
+				Mode:             network.VpnNatRuleModeEgressSnat,
+				Type:             network.VpnNatRuleType("Automatic"),
+				InternalMappings: mappings("10.4.0.0/24"),
+				ExternalMappings: mappings("192.168.21.0/24"),
+			},
+			Valid: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		err := VirtualNetworkGatewayNatRule(v.Input)
+		if valid := err == nil; valid != v.Valid {
+			t.Fatalf("expected %t but got %t (%+v)", v.Valid, valid, err)
+		}
+	}
+}
@@ -4,10 +4,12 @@
 package network
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
 
+	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
@@ -124,7 +126,7 @@ func resourceVirtualHubBgpConnectionCreate(d *pluginsdk.ResourceData, meta inter
 		return fmt.Errorf("creating/updating %s: %+v", id, err)
 	}
 
-	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+	if err := waitForVirtualHubBgpConnectionFuture(ctx, client, future.FutureAPI, id); err != nil {
 		return fmt.Errorf("waiting on creating/updating future for %s: %+v", id, err)
 	}
 
@@ -175,7 +177,7 @@ func resourceVirtualHubBgpConnectionUpdate(d *pluginsdk.ResourceData, meta inter
 		return fmt.Errorf("updating %s: %+v", id, err)
 	}
 
-	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+	if err := waitForVirtualHubBgpConnectionFuture(ctx, client, future.FutureAPI, *id); err != nil {
 		return fmt.Errorf("waiting on updating future for %s: %+v", id, err)
 	}
 
@@ -243,3 +245,42 @@ func resourceVirtualHubBgpConnectionDelete(d *pluginsdk.ResourceData, meta inter
 
 	return nil
 }
+
+// virtualHubBgpConnectionPollStatus queries the long-running operation once, returning its current status and
+// whether it has completed - without blocking until the operation is done
+func virtualHubBgpConnectionPollStatus(ctx context.Context, client *network.VirtualHubBgpConnectionClient, future azure.FutureAPI) (string, bool, error) {
+	done, err := future.DoneWithContext(ctx, client)
+	return future.Status(), done, err
+}
+
+// waitForVirtualHubBgpConnectionFuture waits for the long-running operation to complete, logging its status
+// whilst doing so since creating a BGP Connection can take a considerable amount of time
+func waitForVirtualHubBgpConnectionFuture(ctx context.Context, client *network.VirtualHubBgpConnectionClient, future azure.FutureAPI, id parse.BgpConnectionId) error {
+	for {
+		status, done, err := virtualHubBgpConnectionPollStatus(ctx, client, future)
+		if err != nil {
+			// polling errors (and failures of the operation) are retried/surfaced below
+			log.Printf("[DEBUG] Polling the status of %s: %+v", id, err)
+			break
+		}
+		if done {
+			break
+		}
+
+		log.Printf("[DEBUG] Waiting for %s to complete (status %q)..", id, status)
+
+		delay, ok := future.GetPollingDelay()
+		if !ok {
+			delay = client.PollingDelay
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+
+	// surfaces the final result (including any failure) of the long-running operation, retrying any polling errors
+	return future.WaitForCompletionRef(ctx, client.Client)
+}
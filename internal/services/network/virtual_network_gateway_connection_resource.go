@@ -627,11 +627,12 @@ func getVirtualNetworkGatewayConnectionProperties(d *pluginsdk.ResourceData, vir
 		}
 	}
 
-	if v, ok := d.GetOk("egress_nat_rule_ids"); ok {
+	// omitting the NAT Rules leaves any previously associated NAT Rules as-is, so an empty list is sent when they're removed
+	if v, ok := d.GetOk("egress_nat_rule_ids"); ok || d.HasChange("egress_nat_rule_ids") {
 		props.EgressNatRules = expandVirtualNetworkGatewayConnectionNatRuleIds(v.(*pluginsdk.Set).List())
 	}
 
-	if v, ok := d.GetOk("ingress_nat_rule_ids"); ok {
+	if v, ok := d.GetOk("ingress_nat_rule_ids"); ok || d.HasChange("ingress_nat_rule_ids") {
 		props.IngressNatRules = expandVirtualNetworkGatewayConnectionNatRuleIds(v.(*pluginsdk.Set).List())
 	}
 
@@ -706,6 +707,11 @@ func getVirtualNetworkGatewayConnectionProperties(d *pluginsdk.ResourceData, vir
 		}
 	}
 
+	// likewise omitting the custom BGP addresses leaves any previously configured addresses as-is, so these are cleared explicitly
+	if _, ok := d.GetOk("custom_bgp_addresses"); !ok && d.HasChange("custom_bgp_addresses") {
+		props.GatewayCustomBgpIPAddresses = &[]network.GatewayCustomBgpIPAddressIPConfiguration{}
+	}
+
 	if props.ConnectionType == network.VirtualNetworkGatewayConnectionTypeExpressRoute {
 		if props.Peer == nil || props.Peer.ID == nil {
 			return nil, fmt.Errorf("`express_route_circuit_id` must be specified when `type` is set to `ExpressRoute`")
@@ -727,11 +733,11 @@ func getVirtualNetworkGatewayConnectionProperties(d *pluginsdk.ResourceData, vir
 		}
 	}
 
-	if props.GatewayCustomBgpIPAddresses != nil && props.ConnectionType != network.VirtualNetworkGatewayConnectionTypeIPsec {
+	if props.GatewayCustomBgpIPAddresses != nil && len(*props.GatewayCustomBgpIPAddresses) > 0 && props.ConnectionType != network.VirtualNetworkGatewayConnectionTypeIPsec {
 		return nil, fmt.Errorf("`custom_bgp_addresses` can only be used when `type` is set to `IPsec`")
 	}
 
-	if props.GatewayCustomBgpIPAddresses != nil && virtualNetworkGateway.VirtualNetworkGatewayPropertiesFormat.ActiveActive == utils.Bool(false) {
+	if props.GatewayCustomBgpIPAddresses != nil && len(*props.GatewayCustomBgpIPAddresses) > 0 && virtualNetworkGateway.VirtualNetworkGatewayPropertiesFormat.ActiveActive == utils.Bool(false) {
 		return nil, fmt.Errorf("`custom_bgp_addresses` can only be used when `azurerm_virtual_network_gateway` `active_active` is set enabled`")
 	}
 
@@ -255,7 +255,7 @@ func TestAccVirtualNetworkGatewayConnection_useCustomBgpAddresses(t *testing.T)
 
 	data.ResourceTest(t, r, []acceptance.TestStep{
 		{
-			Config: r.useCustomBgpAddresses(data),
+			Config: r.useCustomBgpAddresses(data, true),
 			Check: acceptance.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
 				check.That(data.ResourceName).Key("custom_bgp_addresses.0.primary").HasValue("169.254.21.2"),
@@ -263,6 +263,14 @@ func TestAccVirtualNetworkGatewayConnection_useCustomBgpAddresses(t *testing.T)
 			),
 		},
 		data.ImportStep(),
+		{
+			Config: r.useCustomBgpAddresses(data, false),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("custom_bgp_addresses.#").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
 	})
 }
 
@@ -287,12 +295,21 @@ func TestAccVirtualNetworkGatewayConnection_natRuleIds(t *testing.T) {
 
 	data.ResourceTest(t, r, []acceptance.TestStep{
 		{
-			Config: r.natRuleIds(data),
+			Config: r.natRuleIds(data, true),
 			Check: acceptance.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
 			),
 		},
 		data.ImportStep(),
+		{
+			Config: r.natRuleIds(data, false),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("egress_nat_rule_ids.#").HasValue("0"),
+				check.That(data.ResourceName).Key("ingress_nat_rule_ids.#").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
 	})
 }
 
@@ -1403,7 +1420,16 @@ resource "azurerm_virtual_network_gateway_connection" "test" {
 `, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger, data.RandomInteger, data.RandomInteger, data.RandomInteger)
 }
 
-func (VirtualNetworkGatewayConnectionResource) useCustomBgpAddresses(data acceptance.TestData) string {
+func (VirtualNetworkGatewayConnectionResource) useCustomBgpAddresses(data acceptance.TestData, customBgpAddresses bool) string {
+	customBgpAddressesBlock := ""
+	if customBgpAddresses {
+		customBgpAddressesBlock = `
+  custom_bgp_addresses {
+    primary   = "169.254.21.2"
+    secondary = "169.254.21.6"
+  }`
+	}
+
 	return fmt.Sprintf(`
 resource "azurerm_resource_group" "test" {
   name     = "acctestRG-%d"
@@ -1515,16 +1541,19 @@ resource "azurerm_virtual_network_gateway_connection" "test" {
   shared_key = "4-v3ry-53cr37-1p53c-5h4r3d-k3y"
 
   enable_bgp = true
-
-  custom_bgp_addresses {
-    primary   = "169.254.21.2"
-    secondary = "169.254.21.6"
-  }
+%s
 }
-`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger, data.RandomInteger, data.RandomInteger, data.RandomInteger, data.RandomInteger)
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger, data.RandomInteger, data.RandomInteger, data.RandomInteger, data.RandomInteger, customBgpAddressesBlock)
 }
 
-func (VirtualNetworkGatewayConnectionResource) natRuleIds(data acceptance.TestData) string {
+func (VirtualNetworkGatewayConnectionResource) natRuleIds(data acceptance.TestData, natRules bool) string {
+	egressNatRuleIds := "[]"
+	ingressNatRuleIds := "[]"
+	if natRules {
+		egressNatRuleIds = "[azurerm_virtual_network_gateway_nat_rule.test.id]"
+		ingressNatRuleIds = "[azurerm_virtual_network_gateway_nat_rule.test2.id]"
+	}
+
 	return fmt.Sprintf(`
 provider "azurerm" {
   features {}
@@ -1630,10 +1659,10 @@ resource "azurerm_virtual_network_gateway_connection" "test" {
   virtual_network_gateway_id = azurerm_virtual_network_gateway.test.id
   local_network_gateway_id   = azurerm_local_network_gateway.test.id
 
-  egress_nat_rule_ids  = [azurerm_virtual_network_gateway_nat_rule.test.id]
-  ingress_nat_rule_ids = [azurerm_virtual_network_gateway_nat_rule.test2.id]
+  egress_nat_rule_ids  = %s
+  ingress_nat_rule_ids = %s
 }
-`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger, data.RandomInteger, data.RandomInteger, data.RandomInteger, data.RandomInteger, data.RandomInteger)
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger, data.RandomInteger, data.RandomInteger, data.RandomInteger, data.RandomInteger, data.RandomInteger, egressNatRuleIds, ingressNatRuleIds)
 }
 
 func (VirtualNetworkGatewayConnectionResource) primaryCustomBgpAddress(data acceptance.TestData) string {
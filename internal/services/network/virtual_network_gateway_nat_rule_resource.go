@@ -4,6 +4,7 @@
 package network
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
@@ -39,6 +40,20 @@ func resourceVirtualNetworkGatewayNatRule() *pluginsdk.Resource {
 			return err
 		}),
 
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(func(ctx context.Context, d *pluginsdk.ResourceDiff, v interface{}) error {
+			// the mappings can't be validated until they're known, for example when they're built using a dynamic block
+			if !d.NewValueKnown("internal_mapping") || !d.NewValueKnown("external_mapping") {
+				return nil
+			}
+
+			return validate.VirtualNetworkGatewayNatRule(network.VirtualNetworkGatewayNatRuleProperties{
+				ExternalMappings: expandVirtualNetworkGatewayNatRuleMappings(d.Get("external_mapping").([]interface{})),
+				InternalMappings: expandVirtualNetworkGatewayNatRuleMappings(d.Get("internal_mapping").([]interface{})),
+				Mode:             network.VpnNatRuleMode(d.Get("mode").(string)),
+				Type:             network.VpnNatRuleType(d.Get("type").(string)),
+			})
+		}),
+
 		Schema: map[string]*pluginsdk.Schema{
 			"name": {
 				Type:         pluginsdk.TypeString,
@@ -164,6 +179,10 @@ func resourceVirtualNetworkGatewayNatRuleCreate(d *pluginsdk.ResourceData, meta
 		props.VirtualNetworkGatewayNatRuleProperties.IPConfigurationID = utils.String(v.(string))
 	}
 
+	if err := validate.VirtualNetworkGatewayNatRule(*props.VirtualNetworkGatewayNatRuleProperties); err != nil {
+		return fmt.Errorf("validating %s: %+v", id, err)
+	}
+
 	future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.VirtualNetworkGatewayName, id.NatRuleName, props)
 	if err != nil {
 		return fmt.Errorf("updating %s: %+v", id, err)
@@ -244,6 +263,10 @@ func resourceVirtualNetworkGatewayNatRuleUpdate(d *pluginsdk.ResourceData, meta
 		props.VirtualNetworkGatewayNatRuleProperties.IPConfigurationID = utils.String(v.(string))
 	}
 
+	if err := validate.VirtualNetworkGatewayNatRule(*props.VirtualNetworkGatewayNatRuleProperties); err != nil {
+		return fmt.Errorf("validating %s: %+v", id, err)
+	}
+
 	future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.VirtualNetworkGatewayName, id.NatRuleName, props)
 	if err != nil {
 		return fmt.Errorf("updating %s: %+v", id, err)
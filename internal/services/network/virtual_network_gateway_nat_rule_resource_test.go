@@ -6,6 +6,7 @@ package network_test
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
@@ -107,6 +108,18 @@ func TestAccVirtualNetworkGatewayNatRule_updatePortRange(t *testing.T) {
 	})
 }
 
+func TestAccVirtualNetworkGatewayNatRule_staticAddressSpaceSizeMismatch(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_virtual_network_gateway_nat_rule", "test")
+	r := VirtualNetworkGatewayNatRuleResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.updatePortRange(data, "10.1.0.0/24", "100", "10.2.0.0/26", "200"),
+			ExpectError: regexp.MustCompile("a `Static` NAT Rule must map address spaces of the same size"),
+		},
+	})
+}
+
 func (r VirtualNetworkGatewayNatRuleResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
 	id, err := parse.VirtualNetworkGatewayNatRuleID(state.ID)
 	if err != nil {
@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package network
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/network/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+func dataSourceVirtualNetworkPeering() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: dataSourceVirtualNetworkPeeringRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"virtual_network_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: commonids.ValidateVirtualNetworkID,
+			},
+
+			"allow_forwarded_traffic": {
+				Type:     pluginsdk.TypeBool,
+				Computed: true,
+			},
+
+			"allow_gateway_transit": {
+				Type:     pluginsdk.TypeBool,
+				Computed: true,
+			},
+
+			"allow_virtual_network_access": {
+				Type:     pluginsdk.TypeBool,
+				Computed: true,
+			},
+
+			"peering_state": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"remote_allow_forwarded_traffic": {
+				Type:     pluginsdk.TypeBool,
+				Computed: true,
+			},
+
+			"remote_virtual_network_id": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"use_remote_gateways": {
+				Type:     pluginsdk.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceVirtualNetworkPeeringRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	virtualNetworkId, err := commonids.ParseVirtualNetworkID(d.Get("virtual_network_id").(string))
+	if err != nil {
+		return err
+	}
+
+	// the Virtual Network can be in another Subscription, so the peering is retrieved using a copy of the client
+	client := *meta.(*clients.Client).Network.VnetPeeringsClient
+	client.SubscriptionID = virtualNetworkId.SubscriptionId
+
+	id := parse.NewVirtualNetworkPeeringID(virtualNetworkId.SubscriptionId, virtualNetworkId.ResourceGroupName, virtualNetworkId.VirtualNetworkName, d.Get("name").(string))
+
+	resp, err := client.Get(ctx, id.ResourceGroup, id.VirtualNetworkName, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return fmt.Errorf("%s was not found", id)
+		}
+		return fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	d.SetId(id.ID())
+
+	d.Set("name", id.Name)
+	d.Set("virtual_network_id", virtualNetworkId.ID())
+
+	if peer := resp.VirtualNetworkPeeringPropertiesFormat; peer != nil {
+		if err := setVirtualNetworkPeeringProperties(d, peer); err != nil {
+			return err
+		}
+	}
+
+	d.Set("remote_allow_forwarded_traffic", remoteVirtualNetworkPeeringAllowForwardedTraffic(ctx, &client, *virtualNetworkId, resp.VirtualNetworkPeeringPropertiesFormat))
+
+	return nil
+}
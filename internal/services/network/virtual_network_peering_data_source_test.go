@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package network_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type VirtualNetworkPeeringDataSource struct{}
+
+func TestAccDataSourceVirtualNetworkPeering_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_virtual_network_peering", "test")
+	r := VirtualNetworkPeeringDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("id").MatchesOtherKey(check.That("azurerm_virtual_network_peering.test").Key("id")),
+				check.That(data.ResourceName).Key("remote_virtual_network_id").MatchesOtherKey(check.That("azurerm_virtual_network.test2").Key("id")),
+				check.That(data.ResourceName).Key("allow_forwarded_traffic").HasValue("true"),
+				check.That(data.ResourceName).Key("allow_gateway_transit").HasValue("false"),
+				check.That(data.ResourceName).Key("allow_virtual_network_access").HasValue("true"),
+				check.That(data.ResourceName).Key("use_remote_gateways").HasValue("false"),
+				check.That(data.ResourceName).Key("peering_state").Exists(),
+			),
+		},
+	})
+}
+
+func (VirtualNetworkPeeringDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_virtual_network" "test1" {
+  name                = "acctestvnet-1-%[1]d"
+  address_space       = ["10.0.1.0/24"]
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+}
+
+resource "azurerm_virtual_network" "test2" {
+  name                = "acctestvnet-2-%[1]d"
+  address_space       = ["10.0.2.0/24"]
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+}
+
+resource "azurerm_virtual_network_peering" "test" {
+  name                      = "acctestpeer-%[1]d"
+  resource_group_name       = azurerm_resource_group.test.name
+  virtual_network_name      = azurerm_virtual_network.test1.name
+  remote_virtual_network_id = azurerm_virtual_network.test2.id
+  allow_forwarded_traffic   = true
+}
+
+data "azurerm_virtual_network_peering" "test" {
+  name               = azurerm_virtual_network_peering.test.name
+  virtual_network_id = azurerm_virtual_network.test1.id
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
@@ -4,7 +4,10 @@
 package network
 
 import (
+	"context"
 	"fmt"
+	"log"
+	"math/rand"
 	"strings"
 	"time"
 
@@ -24,16 +27,22 @@ import (
 
 const virtualNetworkPeeringResourceType = "azurerm_virtual_network_peering"
 
+const (
+	virtualNetworkPeeringRetryBaseDelay = 5 * time.Second
+	virtualNetworkPeeringRetryMaxDelay  = 2 * time.Minute
+)
+
 func resourceVirtualNetworkPeering() *pluginsdk.Resource {
 	return &pluginsdk.Resource{
 		Create: resourceVirtualNetworkPeeringCreate,
 		Read:   resourceVirtualNetworkPeeringRead,
 		Update: resourceVirtualNetworkPeeringUpdate,
 		Delete: resourceVirtualNetworkPeeringDelete,
-		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
-			_, err := parse.VirtualNetworkPeeringID(id)
+		Importer: pluginsdk.ImporterValidatingResourceIdThen(func(id string) error {
+			// IDs copied from the Portal/CLI can differ in casing, so this is normalized during the import
+			_, err := parse.VirtualNetworkPeeringIDInsensitively(id)
 			return err
-		}),
+		}, importVirtualNetworkPeering),
 
 		Timeouts: &pluginsdk.ResourceTimeout{
 			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
@@ -64,10 +73,11 @@ func resourceVirtualNetworkPeering() *pluginsdk.Resource {
 				ValidateFunc: commonids.ValidateVirtualNetworkID,
 			},
 
+			// NOTE: the default for this is controlled by the `virtual_network_peering` block within the Provider `features` block
 			"allow_virtual_network_access": {
 				Type:     pluginsdk.TypeBool,
 				Optional: true,
-				Default:  true,
+				Computed: true,
 			},
 
 			"allow_forwarded_traffic": {
@@ -88,6 +98,20 @@ func resourceVirtualNetworkPeering() *pluginsdk.Resource {
 				Default:  false,
 			},
 
+			"do_not_verify_remote_gateways": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			// NOTE: this isn't returned by the API, it only controls whether the address space of the remote
+			// Virtual Network is synced when the Virtual Network Peering is created or updated
+			"sync_remote_address_space": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
 			"triggers": {
 				Type:     pluginsdk.TypeMap,
 				Optional: true,
@@ -95,6 +119,16 @@ func resourceVirtualNetworkPeering() *pluginsdk.Resource {
 					Type: pluginsdk.TypeString,
 				},
 			},
+
+			"peering_state": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"remote_allow_forwarded_traffic": {
+				Type:     pluginsdk.TypeBool,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -117,54 +151,41 @@ func resourceVirtualNetworkPeeringCreate(d *pluginsdk.ResourceData, meta interfa
 		return tf.ImportAsExistsError("azurerm_virtual_network_peering", id.ID())
 	}
 
+	allowVirtualNetworkAccess := meta.(*clients.Client).Features.VirtualNetworkPeering.AllowVirtualNetworkAccessByDefault
+	if v := d.GetRawConfig().AsValueMap()["allow_virtual_network_access"]; !v.IsNull() {
+		allowVirtualNetworkAccess = v.True()
+	}
+
 	peer := network.VirtualNetworkPeering{
 		VirtualNetworkPeeringPropertiesFormat: &network.VirtualNetworkPeeringPropertiesFormat{
-			AllowVirtualNetworkAccess: pointer.To(d.Get("allow_virtual_network_access").(bool)),
+			AllowVirtualNetworkAccess: pointer.To(allowVirtualNetworkAccess),
 			AllowForwardedTraffic:     pointer.To(d.Get("allow_forwarded_traffic").(bool)),
 			AllowGatewayTransit:       pointer.To(d.Get("allow_gateway_transit").(bool)),
 			UseRemoteGateways:         pointer.To(d.Get("use_remote_gateways").(bool)),
+			DoNotVerifyRemoteGateways: pointer.To(d.Get("do_not_verify_remote_gateways").(bool)),
 			RemoteVirtualNetwork: &network.SubResource{
 				ID: pointer.To(d.Get("remote_virtual_network_id").(string)),
 			},
 		},
 	}
 
-	locks.ByID(virtualNetworkPeeringResourceType)
-	defer locks.UnlockByID(virtualNetworkPeeringResourceType)
-
-	deadline, ok := ctx.Deadline()
-	if !ok {
-		return fmt.Errorf("internal-error: context had no deadline")
-	}
-	stateConf := &pluginsdk.StateChangeConf{
-		Pending: []string{"Pending"},
-		Target:  []string{"Created"},
-		Refresh: func() (interface{}, string, error) {
-			future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.VirtualNetworkName, id.Name, peer, network.SyncRemoteAddressSpaceTrue)
-			if err != nil {
-				if utils.ResponseErrorIsRetryable(err) {
-					return future.Response(), "Pending", err
-				} else {
-					if resp := future.Response(); resp != nil && response.WasBadRequest(resp) && strings.Contains(err.Error(), "ReferencedResourceNotProvisioned") {
-						// Resource is not yet ready, this may be the case if the Vnet was just created or another peering was just initiated.
-						return future.Response(), "Pending", err
-					}
-				}
-
-				return future.Response(), "", err
-			}
+	syncRemoteAddressSpace := expandVirtualNetworkPeeringSyncRemoteAddressSpace(d.Get("sync_remote_address_space").(bool))
 
-			if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
-				return future.Response(), "", err
-			}
+	remoteVirtualNetworkId, err := commonids.ParseVirtualNetworkIDInsensitively(d.Get("remote_virtual_network_id").(string))
+	if err != nil {
+		return fmt.Errorf("parsing `remote_virtual_network_id`: %+v", err)
+	}
 
-			return future.Response(), "Created", nil
-		},
-		Timeout: time.Until(deadline),
-		Delay:   15 * time.Second,
+	crossSubscription := !strings.EqualFold(remoteVirtualNetworkId.SubscriptionId, id.SubscriptionId)
+	if crossSubscription {
+		checkRemoteVirtualNetworkAccess(ctx, meta.(*clients.Client).Network.VnetClient, *remoteVirtualNetworkId)
 	}
-	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
-		return fmt.Errorf("waiting for %s to be created: %+v", id, err)
+
+	if err := createVirtualNetworkPeering(ctx, client, id, peer, syncRemoteAddressSpace); err != nil {
+		if crossSubscription && strings.Contains(err.Error(), "LinkedAuthorizationFailed") {
+			return fmt.Errorf("creating %s: the remote %s is in a different Subscription, peering with it requires the `Microsoft.Network/virtualNetworks/peer/action` permission on the remote Virtual Network: %+v", id, remoteVirtualNetworkId, err)
+		}
+		return err
 	}
 
 	d.SetId(id.ID())
@@ -172,6 +193,96 @@ func resourceVirtualNetworkPeeringCreate(d *pluginsdk.ResourceData, meta interfa
 	return resourceVirtualNetworkPeeringRead(d, meta)
 }
 
+// checkRemoteVirtualNetworkAccess checks whether the remote Virtual Network in another Subscription can be retrieved,
+// logging a warning when access is denied since the peering will likely fail to be created. This doesn't block the
+// creation of the peering, since only the `peer` action is required on the remote Virtual Network.
+func checkRemoteVirtualNetworkAccess(ctx context.Context, client *network.VirtualNetworksClient, remoteId commonids.VirtualNetworkId) {
+	remoteClient := *client
+	remoteClient.SubscriptionID = remoteId.SubscriptionId
+
+	resp, err := remoteClient.Get(ctx, remoteId.ResourceGroupName, remoteId.VirtualNetworkName, "")
+	if err != nil {
+		switch {
+		case response.WasForbidden(resp.Response.Response):
+			log.Printf("[WARN] access to the remote %s was denied, creating a peering with it requires the `Microsoft.Network/virtualNetworks/peer/action` permission in Subscription %q: %+v", remoteId, remoteId.SubscriptionId, err)
+		case response.WasNotFound(resp.Response.Response):
+			log.Printf("[WARN] the remote %s was not found", remoteId)
+		default:
+			log.Printf("[DEBUG] unable to check access to the remote %s: %+v", remoteId, err)
+		}
+	}
+}
+
+// createVirtualNetworkPeering creates (or updates) the Virtual Network Peering, retrying whilst the referenced Virtual
+// Networks aren't ready. The lock on `virtualNetworkPeeringResourceType` is taken for each attempt and released whilst
+// waiting between attempts, so callers mustn't hold it.
+func createVirtualNetworkPeering(ctx context.Context, client *network.VirtualNetworkPeeringsClient, id parse.VirtualNetworkPeeringId, peer network.VirtualNetworkPeering, syncRemoteAddressSpace network.SyncRemoteAddressSpace) error {
+	for attempt := 0; ; attempt++ {
+		retryable, err := createVirtualNetworkPeeringAttempt(ctx, client, id, peer, syncRemoteAddressSpace)
+		if err == nil {
+			return nil
+		}
+		if !retryable {
+			return fmt.Errorf("waiting for %s to be created: %+v", id, err)
+		}
+
+		// back off with a random jitter, so that many peerings being created in parallel don't retry in lockstep
+		delay := virtualNetworkPeeringRetryDelay(attempt)
+		log.Printf("[DEBUG] %s isn't ready to be created, retrying in %s: %+v", id, delay, err)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for %s to be created: %+v", id, err)
+		case <-time.After(delay):
+		}
+	}
+}
+
+// createVirtualNetworkPeeringAttempt makes a single attempt to create (or update) the Virtual Network Peering whilst
+// holding the lock on `virtualNetworkPeeringResourceType`, returning whether a failure can be retried
+func createVirtualNetworkPeeringAttempt(ctx context.Context, client *network.VirtualNetworkPeeringsClient, id parse.VirtualNetworkPeeringId, peer network.VirtualNetworkPeering, syncRemoteAddressSpace network.SyncRemoteAddressSpace) (bool, error) {
+	locks.ByID(virtualNetworkPeeringResourceType)
+	defer locks.UnlockByID(virtualNetworkPeeringResourceType)
+
+	future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.VirtualNetworkName, id.Name, peer, syncRemoteAddressSpace)
+	if err != nil {
+		retryable := utils.ResponseErrorIsRetryable(err)
+		if resp := future.Response(); resp != nil && response.WasBadRequest(resp) && strings.Contains(err.Error(), "ReferencedResourceNotProvisioned") {
+			// Resource is not yet ready, this may be the case if the Vnet was just created or another peering was just initiated.
+			retryable = true
+		}
+		return retryable, err
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return false, err
+	}
+
+	return false, nil
+}
+
+// virtualNetworkPeeringRetryDelay returns how long to wait before the next attempt to create the Virtual Network Peering,
+// which doubles with each attempt (up to a maximum) and is randomised between half and the whole of that delay
+func virtualNetworkPeeringRetryDelay(attempt int) time.Duration {
+	delay := virtualNetworkPeeringRetryMaxDelay
+	if attempt < 10 {
+		if v := virtualNetworkPeeringRetryBaseDelay << attempt; v < delay {
+			delay = v
+		}
+	}
+
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// expandVirtualNetworkPeeringSyncRemoteAddressSpace returns the value for the `syncRemoteAddressSpace` query parameter,
+// which is omitted from the request (and the remote address space left as-is) when syncing is disabled
+func expandVirtualNetworkPeeringSyncRemoteAddressSpace(input bool) network.SyncRemoteAddressSpace {
+	if input {
+		return network.SyncRemoteAddressSpaceTrue
+	}
+
+	return ""
+}
+
 func resourceVirtualNetworkPeeringUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 	client := meta.(*clients.Client).Network.VnetPeeringsClient
 	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
@@ -202,13 +313,16 @@ func resourceVirtualNetworkPeeringUpdate(d *pluginsdk.ResourceData, meta interfa
 	if d.HasChange("use_remote_gateways") {
 		existing.VirtualNetworkPeeringPropertiesFormat.UseRemoteGateways = pointer.To(d.Get("use_remote_gateways").(bool))
 	}
+	if d.HasChange("do_not_verify_remote_gateways") {
+		existing.VirtualNetworkPeeringPropertiesFormat.DoNotVerifyRemoteGateways = pointer.To(d.Get("do_not_verify_remote_gateways").(bool))
+	}
 	if d.HasChange("remote_virtual_network_id") {
 		existing.VirtualNetworkPeeringPropertiesFormat.RemoteVirtualNetwork = &network.SubResource{
 			ID: pointer.To(d.Get("remote_virtual_network_id").(string)),
 		}
 	}
 
-	future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.VirtualNetworkName, id.Name, existing, network.SyncRemoteAddressSpaceTrue)
+	future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.VirtualNetworkName, id.Name, existing, expandVirtualNetworkPeeringSyncRemoteAddressSpace(d.Get("sync_remote_address_space").(bool)))
 	if err != nil {
 		return fmt.Errorf("updating %s: %+v", *id, err)
 	}
@@ -243,22 +357,85 @@ func resourceVirtualNetworkPeeringRead(d *pluginsdk.ResourceData, meta interface
 	d.Set("virtual_network_name", id.VirtualNetworkName)
 
 	if peer := resp.VirtualNetworkPeeringPropertiesFormat; peer != nil {
-		d.Set("allow_virtual_network_access", peer.AllowVirtualNetworkAccess)
-		d.Set("allow_forwarded_traffic", peer.AllowForwardedTraffic)
-		d.Set("allow_gateway_transit", peer.AllowGatewayTransit)
-		d.Set("use_remote_gateways", peer.UseRemoteGateways)
-
-		remoteVirtualNetworkId := ""
-		if network := peer.RemoteVirtualNetwork; network != nil {
-			parsed, err := commonids.ParseVirtualNetworkIDInsensitively(*network.ID)
-			if err != nil {
-				return fmt.Errorf("parsing %q as a Virtual Network ID: %+v", *network.ID, err)
+		if err := setVirtualNetworkPeeringProperties(d, peer); err != nil {
+			return err
+		}
+
+		// NOTE: this isn't always returned by the API, in which case the value from the config is retained
+		if peer.DoNotVerifyRemoteGateways != nil {
+			d.Set("do_not_verify_remote_gateways", *peer.DoNotVerifyRemoteGateways)
+		}
+	}
+
+	virtualNetworkId := commonids.NewVirtualNetworkID(id.SubscriptionId, id.ResourceGroup, id.VirtualNetworkName)
+	d.Set("remote_allow_forwarded_traffic", remoteVirtualNetworkPeeringAllowForwardedTraffic(ctx, client, virtualNetworkId, resp.VirtualNetworkPeeringPropertiesFormat))
+
+	return nil
+}
+
+// setVirtualNetworkPeeringProperties sets the properties of the Virtual Network Peering, which are shared between
+// the `azurerm_virtual_network_peering` Resource and Data Source
+func setVirtualNetworkPeeringProperties(d *pluginsdk.ResourceData, peer *network.VirtualNetworkPeeringPropertiesFormat) error {
+	d.Set("allow_virtual_network_access", peer.AllowVirtualNetworkAccess)
+	d.Set("allow_forwarded_traffic", peer.AllowForwardedTraffic)
+	d.Set("allow_gateway_transit", peer.AllowGatewayTransit)
+	d.Set("use_remote_gateways", peer.UseRemoteGateways)
+	d.Set("peering_state", string(peer.PeeringState))
+
+	remoteVirtualNetworkId := ""
+	if remote := peer.RemoteVirtualNetwork; remote != nil && remote.ID != nil {
+		parsed, err := commonids.ParseVirtualNetworkIDInsensitively(*remote.ID)
+		if err != nil {
+			return fmt.Errorf("parsing %q as a Virtual Network ID: %+v", *remote.ID, err)
+		}
+		remoteVirtualNetworkId = parsed.ID()
+	}
+	d.Set("remote_virtual_network_id", remoteVirtualNetworkId)
+
+	return nil
+}
+
+// remoteVirtualNetworkPeeringAllowForwardedTraffic returns whether forwarded traffic is allowed by the mirror of a Virtual
+// Network Peering (that is, the peering from the remote Virtual Network back to the local one), so that a mismatch between
+// the two directions can be detected. The mirror only exists once the peering is `Connected`, so the remote peerings are
+// only looked up then - and nil is returned when the mirror can't be found, since the remote Virtual Network may be in a
+// Subscription or Tenant which the Provider doesn't have access to, in which case the value is unknown rather than false.
+func remoteVirtualNetworkPeeringAllowForwardedTraffic(ctx context.Context, client *network.VirtualNetworkPeeringsClient, virtualNetworkId commonids.VirtualNetworkId, peer *network.VirtualNetworkPeeringPropertiesFormat) *bool {
+	if peer == nil || peer.PeeringState != network.VirtualNetworkPeeringStateConnected || peer.RemoteVirtualNetwork == nil || peer.RemoteVirtualNetwork.ID == nil {
+		return nil
+	}
+
+	remoteId, err := commonids.ParseVirtualNetworkIDInsensitively(*peer.RemoteVirtualNetwork.ID)
+	if err != nil {
+		log.Printf("[DEBUG] unable to parse %q as a Virtual Network ID: %+v", *peer.RemoteVirtualNetwork.ID, err)
+		return nil
+	}
+
+	// the remote Virtual Network can be in another Subscription, so the peerings are listed using a copy of the client
+	remoteClient := *client
+	remoteClient.SubscriptionID = remoteId.SubscriptionId
+
+	iterator, err := remoteClient.ListComplete(ctx, remoteId.ResourceGroupName, remoteId.VirtualNetworkName)
+	if err != nil {
+		log.Printf("[WARN] unable to list the Virtual Network Peerings for %s, `remote_allow_forwarded_traffic` will be left unset: %+v", remoteId, err)
+		return nil
+	}
+
+	for iterator.NotDone() {
+		peering := iterator.Value()
+		if props := peering.VirtualNetworkPeeringPropertiesFormat; props != nil && props.RemoteVirtualNetwork != nil && props.RemoteVirtualNetwork.ID != nil {
+			if strings.EqualFold(*props.RemoteVirtualNetwork.ID, virtualNetworkId.ID()) {
+				return pointer.To(pointer.From(props.AllowForwardedTraffic))
 			}
-			remoteVirtualNetworkId = parsed.ID()
 		}
-		d.Set("remote_virtual_network_id", remoteVirtualNetworkId)
+
+		if err := iterator.NextWithContext(ctx); err != nil {
+			log.Printf("[WARN] unable to list the Virtual Network Peerings for %s, `remote_allow_forwarded_traffic` will be left unset: %+v", remoteId, err)
+			return nil
+		}
 	}
 
+	log.Printf("[WARN] no Virtual Network Peering was found from %s to %s, `remote_allow_forwarded_traffic` will be left unset", remoteId, virtualNetworkId)
 	return nil
 }
 
@@ -286,3 +463,43 @@ func resourceVirtualNetworkPeeringDelete(d *pluginsdk.ResourceData, meta interfa
 
 	return err
 }
+
+// importVirtualNetworkPeering checks that the Virtual Network Peering exists prior to importing it, since otherwise
+// the subsequent read would remove a stale ID from the state, rather than surfacing the typo to the user
+func importVirtualNetworkPeering(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) ([]*pluginsdk.ResourceData, error) {
+	client := meta.(*clients.Client).Network.VnetPeeringsClient
+	vnetClient := meta.(*clients.Client).Network.VnetClient
+
+	id, err := parse.VirtualNetworkPeeringID(d.Id())
+	if err != nil {
+		// fall back to parsing the ID insensitively, normalizing the casing of the ID stored in the state
+		id, err = parse.VirtualNetworkPeeringIDInsensitively(d.Id())
+		if err != nil {
+			return []*pluginsdk.ResourceData{}, err
+		}
+		d.SetId(id.ID())
+	}
+
+	resp, err := client.Get(ctx, id.ResourceGroup, id.VirtualNetworkName, id.Name)
+	if err != nil {
+		if !utils.ResponseWasNotFound(resp.Response) {
+			return []*pluginsdk.ResourceData{}, fmt.Errorf("retrieving %s: %+v", id, err)
+		}
+
+		vnetId := commonids.NewVirtualNetworkID(id.SubscriptionId, id.ResourceGroup, id.VirtualNetworkName)
+		vnet, err := vnetClient.Get(ctx, vnetId.ResourceGroupName, vnetId.VirtualNetworkName, "")
+		if err != nil {
+			if utils.ResponseWasNotFound(vnet.Response) {
+				return []*pluginsdk.ResourceData{}, fmt.Errorf("importing %s: the %s was not found", id, vnetId)
+			}
+			return []*pluginsdk.ResourceData{}, fmt.Errorf("retrieving %s: %+v", vnetId, err)
+		}
+
+		return []*pluginsdk.ResourceData{}, fmt.Errorf("importing %s: the Virtual Network Peering was not found within the %s", id, vnetId)
+	}
+
+	// this isn't returned by the API, so default it as it would be for a new Virtual Network Peering
+	d.Set("sync_remote_address_space", true)
+
+	return []*pluginsdk.ResourceData{d}, nil
+}
@@ -6,6 +6,8 @@ package network_test
 import (
 	"context"
 	"fmt"
+	"os"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
@@ -31,6 +33,27 @@ func TestAccVirtualNetworkPeering_basic(t *testing.T) {
 				check.That(secondResourceName).ExistsInAzure(r),
 				check.That(data.ResourceName).Key("allow_virtual_network_access").HasValue("true"),
 				check.That(secondResourceName).Key("allow_virtual_network_access").HasValue("true"),
+				check.That(data.ResourceName).Key("peering_state").Exists(),
+				check.That(secondResourceName).Key("peering_state").HasValue("Connected"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccVirtualNetworkPeering_allowVirtualNetworkAccessDisabledByDefault(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_virtual_network_peering", "test1")
+	r := VirtualNetworkPeeringResource{}
+	secondResourceName := "azurerm_virtual_network_peering.test2"
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.allowVirtualNetworkAccessDisabledByDefault(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(secondResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("allow_virtual_network_access").HasValue("false"),
+				check.That(secondResourceName).Key("allow_virtual_network_access").HasValue("true"),
 			),
 		},
 		data.ImportStep(),
@@ -60,6 +83,33 @@ func TestAccVirtualNetworkPeering_withTriggers(t *testing.T) {
 	})
 }
 
+func TestAccVirtualNetworkPeering_syncRemoteAddressSpaceDisabled(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_virtual_network_peering", "test1")
+	r := VirtualNetworkPeeringResource{}
+	secondResourceName := "azurerm_virtual_network_peering.test2"
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.syncRemoteAddressSpace(data, false),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(secondResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("sync_remote_address_space").HasValue("false"),
+			),
+		},
+		// sync_remote_address_space isn't returned by the API
+		data.ImportStep("sync_remote_address_space"),
+		{
+			Config: r.syncRemoteAddressSpace(data, true),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("sync_remote_address_space").HasValue("true"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccVirtualNetworkPeering_requiresImport(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_virtual_network_peering", "test1")
 	r := VirtualNetworkPeeringResource{}
@@ -77,6 +127,46 @@ func TestAccVirtualNetworkPeering_requiresImport(t *testing.T) {
 	})
 }
 
+func TestAccVirtualNetworkPeering_importNonExistent(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_virtual_network_peering", "test1")
+	r := VirtualNetworkPeeringResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+		},
+		{
+			ResourceName:  data.ResourceName,
+			ImportState:   true,
+			ImportStateId: fmt.Sprintf("/subscriptions/%s/resourceGroups/acctestRG-%d/providers/Microsoft.Network/virtualNetworks/acctestvirtnet-1-%d/virtualNetworkPeerings/acctestpeer-missing-%d", os.Getenv("ARM_SUBSCRIPTION_ID"), data.RandomInteger, data.RandomInteger, data.RandomInteger),
+			ExpectError:   regexp.MustCompile("the Virtual Network Peering was not found"),
+		},
+		{
+			ResourceName:  data.ResourceName,
+			ImportState:   true,
+			ImportStateId: fmt.Sprintf("/subscriptions/%s/resourceGroups/acctestRG-%d/providers/Microsoft.Network/virtualNetworks/acctestvirtnet-missing-%d/virtualNetworkPeerings/acctestpeer-1-%d", os.Getenv("ARM_SUBSCRIPTION_ID"), data.RandomInteger, data.RandomInteger, data.RandomInteger),
+			ExpectError:   regexp.MustCompile("Virtual Network .* was not found"),
+		},
+	})
+}
+
+func TestAccVirtualNetworkPeering_importInsensitively(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_virtual_network_peering", "test1")
+	r := VirtualNetworkPeeringResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+		},
+		{
+			ResourceName:      data.ResourceName,
+			ImportState:       true,
+			ImportStateId:     fmt.Sprintf("/subscriptions/%s/resourceGroups/acctestRG-%d/providers/microsoft.network/virtualnetworks/acctestvirtnet-1-%d/virtualnetworkpeerings/acctestpeer-1-%d", os.Getenv("ARM_SUBSCRIPTION_ID"), data.RandomInteger, data.RandomInteger, data.RandomInteger),
+			ImportStateVerify: true,
+		},
+	})
+}
+
 func TestAccVirtualNetworkPeering_disappears(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_virtual_network_peering", "test1")
 	r := VirtualNetworkPeeringResource{}
@@ -118,6 +208,15 @@ func TestAccVirtualNetworkPeering_update(t *testing.T) {
 				check.That(secondResourceName).Key("allow_forwarded_traffic").HasValue("true"),
 			),
 		},
+
+		{
+			// the remote peering is only guaranteed to have been updated once both peerings have been refreshed
+			Config: r.basicUpdate(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("remote_allow_forwarded_traffic").HasValue("true"),
+				check.That(secondResourceName).Key("remote_allow_forwarded_traffic").HasValue("true"),
+			),
+		},
 	})
 }
 
@@ -179,6 +278,36 @@ resource "azurerm_virtual_network_peering" "test2" {
 `, template, data.RandomInteger)
 }
 
+func (r VirtualNetworkPeeringResource) allowVirtualNetworkAccessDisabledByDefault(data acceptance.TestData) string {
+	template := r.template(data)
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {
+    virtual_network_peering {
+      allow_virtual_network_access_by_default = false
+    }
+  }
+}
+
+%[1]s
+
+resource "azurerm_virtual_network_peering" "test1" {
+  name                      = "acctestpeer-1-%[2]d"
+  resource_group_name       = azurerm_resource_group.test.name
+  virtual_network_name      = azurerm_virtual_network.test1.name
+  remote_virtual_network_id = azurerm_virtual_network.test2.id
+}
+
+resource "azurerm_virtual_network_peering" "test2" {
+  name                         = "acctestpeer-2-%[2]d"
+  resource_group_name          = azurerm_resource_group.test.name
+  virtual_network_name         = azurerm_virtual_network.test2.name
+  remote_virtual_network_id    = azurerm_virtual_network.test1.id
+  allow_virtual_network_access = true
+}
+`, template, data.RandomInteger)
+}
+
 func (r VirtualNetworkPeeringResource) withTriggers(data acceptance.TestData) string {
 	template := r.template(data)
 	return fmt.Sprintf(`
@@ -212,6 +341,34 @@ resource "azurerm_virtual_network_peering" "test2" {
 `, template, data.RandomInteger)
 }
 
+func (r VirtualNetworkPeeringResource) syncRemoteAddressSpace(data acceptance.TestData, enabled bool) string {
+	template := r.template(data)
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+%[1]s
+
+resource "azurerm_virtual_network_peering" "test1" {
+  name                         = "acctestpeer-1-%[2]d"
+  resource_group_name          = azurerm_resource_group.test.name
+  virtual_network_name         = azurerm_virtual_network.test1.name
+  remote_virtual_network_id    = azurerm_virtual_network.test2.id
+  allow_virtual_network_access = true
+  sync_remote_address_space    = %[3]t
+}
+
+resource "azurerm_virtual_network_peering" "test2" {
+  name                         = "acctestpeer-2-%[2]d"
+  resource_group_name          = azurerm_resource_group.test.name
+  virtual_network_name         = azurerm_virtual_network.test2.name
+  remote_virtual_network_id    = azurerm_virtual_network.test1.id
+  allow_virtual_network_access = true
+}
+`, template, data.RandomInteger, enabled)
+}
+
 func (r VirtualNetworkPeeringResource) requiresImport(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %s
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package network
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVirtualNetworkPeeringRetryDelay(t *testing.T) {
+	testData := []struct {
+		Attempt int
+		Min     time.Duration
+		Max     time.Duration
+	}{
+		{
+			Attempt: 0,
+			Min:     virtualNetworkPeeringRetryBaseDelay / 2,
+			Max:     virtualNetworkPeeringRetryBaseDelay,
+		},
+		{
+			Attempt: 1,
+			Min:     virtualNetworkPeeringRetryBaseDelay,
+			Max:     virtualNetworkPeeringRetryBaseDelay * 2,
+		},
+		{
+			Attempt: 3,
+			Min:     virtualNetworkPeeringRetryBaseDelay * 4,
+			Max:     virtualNetworkPeeringRetryBaseDelay * 8,
+		},
+		{
+			// the delay is capped at the maximum
+			Attempt: 5,
+			Min:     virtualNetworkPeeringRetryMaxDelay / 2,
+			Max:     virtualNetworkPeeringRetryMaxDelay,
+		},
+		{
+			// large attempts mustn't overflow the delay
+			Attempt: 100,
+			Min:     virtualNetworkPeeringRetryMaxDelay / 2,
+			Max:     virtualNetworkPeeringRetryMaxDelay,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing attempt %d", v.Attempt)
+
+		// the delay is randomised, so check it's within the bounds a number of times
+		for i := 0; i < 100; i++ {
+			actual := virtualNetworkPeeringRetryDelay(v.Attempt)
+			if actual < v.Min || actual > v.Max {
+				t.Fatalf("expected the delay for attempt %d to be between %s and %s but got %s", v.Attempt, v.Min, v.Max, actual)
+			}
+		}
+	}
+}
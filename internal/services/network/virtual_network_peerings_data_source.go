@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package network
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/network/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+	"github.com/tombuildsstuff/kermit/sdk/network/2022-07-01/network"
+)
+
+func dataSourceVirtualNetworkPeerings() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: dataSourceVirtualNetworkPeeringsRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"virtual_network_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: commonids.ValidateVirtualNetworkID,
+			},
+
+			"peerings": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"remote_virtual_network_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceVirtualNetworkPeeringsRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := commonids.ParseVirtualNetworkID(d.Get("virtual_network_id").(string))
+	if err != nil {
+		return err
+	}
+
+	// the Virtual Network can be in another Subscription, so the peerings are listed using a copy of the client
+	client := *meta.(*clients.Client).Network.VnetPeeringsClient
+	client.SubscriptionID = id.SubscriptionId
+
+	peerings := make([]network.VirtualNetworkPeering, 0)
+	iterator, err := client.ListComplete(ctx, id.ResourceGroupName, id.VirtualNetworkName)
+	if err != nil {
+		return fmt.Errorf("listing Virtual Network Peerings within %s: %+v", id, err)
+	}
+	for iterator.NotDone() {
+		peerings = append(peerings, iterator.Value())
+		if err := iterator.NextWithContext(ctx); err != nil {
+			return fmt.Errorf("listing Virtual Network Peerings within %s: %+v", id, err)
+		}
+	}
+
+	d.SetId(id.ID())
+
+	if err := d.Set("peerings", flattenVirtualNetworkPeeringsList(id, peerings)); err != nil {
+		return fmt.Errorf("setting `peerings`: %+v", err)
+	}
+
+	return nil
+}
+
+func flattenVirtualNetworkPeeringsList(id *commonids.VirtualNetworkId, input []network.VirtualNetworkPeering) []interface{} {
+	output := make([]interface{}, 0)
+
+	for _, item := range input {
+		if item.Name == nil {
+			continue
+		}
+
+		remoteVirtualNetworkId := ""
+		if props := item.VirtualNetworkPeeringPropertiesFormat; props != nil && props.RemoteVirtualNetwork != nil && props.RemoteVirtualNetwork.ID != nil {
+			remoteVirtualNetworkId = *props.RemoteVirtualNetwork.ID
+		}
+
+		output = append(output, map[string]interface{}{
+			"id":                        parse.NewVirtualNetworkPeeringID(id.SubscriptionId, id.ResourceGroupName, id.VirtualNetworkName, *item.Name).ID(),
+			"name":                      *item.Name,
+			"remote_virtual_network_id": remoteVirtualNetworkId,
+		})
+	}
+
+	return output
+}
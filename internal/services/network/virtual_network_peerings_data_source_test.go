@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package network_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type VirtualNetworkPeeringsDataSource struct{}
+
+func TestAccDataSourceVirtualNetworkPeerings_multiple(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_virtual_network_peerings", "test")
+	r := VirtualNetworkPeeringsDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.multiple(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("peerings.#").HasValue("2"),
+				check.That(data.ResourceName).Key("peerings.0.id").Exists(),
+				check.That(data.ResourceName).Key("peerings.0.name").Exists(),
+				check.That(data.ResourceName).Key("peerings.0.remote_virtual_network_id").Exists(),
+				check.That(data.ResourceName).Key("peerings.1.id").Exists(),
+				check.That(data.ResourceName).Key("peerings.1.name").Exists(),
+				check.That(data.ResourceName).Key("peerings.1.remote_virtual_network_id").Exists(),
+			),
+		},
+	})
+}
+
+func (VirtualNetworkPeeringsDataSource) multiple(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_virtual_network" "test1" {
+  name                = "acctestvnet-1-%[1]d"
+  address_space       = ["10.0.1.0/24"]
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+}
+
+resource "azurerm_virtual_network" "test2" {
+  name                = "acctestvnet-2-%[1]d"
+  address_space       = ["10.0.2.0/24"]
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+}
+
+resource "azurerm_virtual_network" "test3" {
+  name                = "acctestvnet-3-%[1]d"
+  address_space       = ["10.0.3.0/24"]
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+}
+
+resource "azurerm_virtual_network_peering" "test1" {
+  name                      = "peer-1to2"
+  resource_group_name       = azurerm_resource_group.test.name
+  virtual_network_name      = azurerm_virtual_network.test1.name
+  remote_virtual_network_id = azurerm_virtual_network.test2.id
+}
+
+resource "azurerm_virtual_network_peering" "test2" {
+  name                      = "peer-1to3"
+  resource_group_name       = azurerm_resource_group.test.name
+  virtual_network_name      = azurerm_virtual_network.test1.name
+  remote_virtual_network_id = azurerm_virtual_network.test3.id
+}
+
+data "azurerm_virtual_network_peerings" "test" {
+  virtual_network_id = azurerm_virtual_network.test1.id
+
+  depends_on = [
+    azurerm_virtual_network_peering.test1,
+    azurerm_virtual_network_peering.test2,
+  ]
+}
+`, data.RandomInteger, data.Locations.Primary)
+}